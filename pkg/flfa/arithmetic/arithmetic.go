@@ -1,72 +1,44 @@
 package main
 
 import (
-	"flfa/ll1"
+	"bufio"
+	"flfa/calculator"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 )
 
 func main() {
-	nonterminalAlphabet := []rune{'S', 't', 'T', 'r', 'R', 'v'}
-	terminalAlphabet := []rune{'d', '+', '-', '*', '/', '(', ')', '$'}
-	startSymbol := 'S'
-	ll1Table := ll1.LL1Table{
-		'S': {
-			'(': {'t', '$'},
-			'd': {'t', '$'},
-		},
-		't': {
-			'(': {'r', 'T'},
-			'd': {'r', 'T'},
-		},
-		'T': {
-			'+': {'+', 'r', 'T'},
-			'-': {'-', 'r', 'T'},
-			')': {},
-			'$': {},
-		},
-		'r': {
-			'(': {'v', 'R'},
-			'd': {'v', 'R'},
-		},
-		'R': {
-			'+': {},
-			'-': {},
-			'*': {'*', 'v', 'R'},
-			'/': {'/', 'v', 'R'},
-			')': {},
-			'$': {},
-		},
-		'v': {
-			'(': {'(', 't', ')'},
-			'd': {'d'},
-		},
-	}
-	regexesReplaces := []ll1.RegexReplace{
-		{Regex: "\\d+\\.?\\d*|\\.\\d+", Replacement: "d"},
+	fmt.Println("Enter an arithmetic expression.")
+
+	reader := bufio.NewReader(os.Stdin)
+	str, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		fmt.Print(err)
+		return
 	}
+	str = strings.TrimRight(str, "\r\n")
 
-	ll1, err := ll1.NewLL1(nonterminalAlphabet, terminalAlphabet, startSymbol, ll1Table, regexesReplaces)
+	syntaxErrors, err := calculator.Diagnose(str)
 	if err != nil {
 		fmt.Print(err)
 		return
 	}
-	var str string
-
-	fmt.Println("Enter an arithmetic expression.")
-	fmt.Scan(&str)
 
-	for _, char := range str {
-		if char == '$' || char == 'd' {
-			fmt.Println("the string entered contains either the character '$' or 'd' which cannot be used")
-			return
+	if len(syntaxErrors) != 0 {
+		for _, syntaxError := range syntaxErrors {
+			fmt.Println(syntaxError)
+			fmt.Print(syntaxError.Snippet())
 		}
+		return
 	}
 
-	err = ll1.Solve(str + "$")
+	value, err := calculator.Evaluate(str)
 	if err != nil {
 		fmt.Print(err)
 		return
 	}
 
-	fmt.Printf("the string '%v' is valid", str)
+	fmt.Printf("%v = %v", str, value)
 }
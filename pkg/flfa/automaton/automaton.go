@@ -0,0 +1,100 @@
+/*
+	Package automaton loads and saves DFAs and NFAs as JSON or YAML definition files.
+
+	A DFA definition maps every state and symbol to a single state:
+
+		type: dfa
+		states: [q0, q1]
+		alphabet: [a, b]
+		delta:
+		  q0: {a: q1, b: q0}
+		  q1: {a: q1, b: q0}
+		start: q0
+		accepting: [q1]
+
+	An NFA definition maps every state and symbol to a list of states, may use the symbol 'ε' for epsilon moves,
+	and has a list of starting states. Symbols without any target states may be left out of delta:
+
+		type: nfa
+		states: [q0, q1]
+		alphabet: [a, b]
+		delta:
+		  q0: {a: [q0, q1], b: [q0]}
+		  q1: {ε: [q0]}
+		start: [q0]
+		accepting: [q1]
+
+	Every symbol is written as a string of exactly one character.
+	The same fields are used in JSON, and the format of a file is chosen by its extension.
+*/
+package automaton
+
+import (
+	"flfa/dfa"
+	"flfa/nfa"
+	"fmt"
+)
+
+type Kind string
+
+const (
+	DFA Kind = "dfa"
+	NFA Kind = "nfa"
+)
+
+/*
+	A validated DFA or NFA loaded from a definition.
+	Only the field matching the kind is set.
+*/
+type Automaton struct {
+	Kind Kind
+	DFA  dfa.DFA
+	NFA  nfa.NFA
+}
+
+/*
+	Solves the automaton given a string and reports if the string is accepted.
+*/
+func (automaton *Automaton) Accepts(str string) (bool, error) {
+	if automaton.Kind == NFA {
+		_, isAccepting, err := automaton.NFA.SolveSet(str)
+		return isAccepting, err
+	}
+
+	_, isAccepting, err := automaton.DFA.Solve(str)
+	return isAccepting, err
+}
+
+/*
+	Gets the automaton as a DFA, converting an NFA with the subset construction.
+*/
+func (automaton *Automaton) ToDFA() (dfa.DFA, error) {
+	if automaton.Kind == NFA {
+		return automaton.NFA.ToDFA()
+	}
+
+	return automaton.DFA, nil
+}
+
+/*
+	Renders the automaton as a Graphviz DOT digraph.
+*/
+func (automaton *Automaton) ToDOT() string {
+	if automaton.Kind == NFA {
+		return automaton.NFA.ToDOT()
+	}
+
+	return automaton.DFA.ToDOT()
+}
+
+/*
+	Converts a definition symbol into a rune.
+*/
+func parseSymbol(str string) (rune, error) {
+	runes := []rune(str)
+	if len(runes) != 1 {
+		return 0, fmt.Errorf("the symbol '%v' must be exactly one character", str)
+	}
+
+	return runes[0], nil
+}
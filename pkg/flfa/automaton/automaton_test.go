@@ -0,0 +1,120 @@
+package automaton
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	var tests = []struct {
+		path    string
+		kind    Kind
+		accepts map[string]bool
+	}{
+		{"../machines/2mod7.yaml", DFA, map[string]bool{"10": true, "1001": true, "11": false, "": false}},
+		{"../machines/mwgc.yaml", DFA, map[string]bool{"gmwgcmg": true, "gmcgwmg": true, "gmwmg": false}},
+		{"../machines/exercise-7.yaml", NFA, map[string]bool{"baab": true, "abab": false}},
+		{"testdata/aStarBStar.json", NFA, map[string]bool{"": true, "aabb": true, "aba": false}},
+	}
+
+	for _, tt := range tests {
+		automaton, err := Load(tt.path)
+		assert.Equal(t, nil, err, tt.path)
+		assert.Equal(t, tt.kind, automaton.Kind, tt.path)
+
+		for str, want := range tt.accepts {
+			isAccepting, err := automaton.Accepts(str)
+
+			assert.Equal(t, nil, err)
+			assert.Equal(t, want, isAccepting, "%v with the string '%v'", tt.path, str)
+		}
+
+		// Writing and reading back the automaton gives the same automaton in both formats
+		for _, format := range []Format{JSON, YAML} {
+			data, err := Marshal(automaton, format)
+			assert.Equal(t, nil, err)
+
+			parsed, err := Parse(data, format)
+			assert.Equal(t, nil, err)
+			assert.Equal(t, automaton, parsed, "%v written as %v", tt.path, format)
+		}
+	}
+}
+
+func TestMarshalNFA(t *testing.T) {
+	automaton, err := Load("testdata/aStarBStar.json")
+	assert.Equal(t, nil, err)
+
+	data, err := Marshal(automaton, YAML)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `type: nfa
+states:
+  - q0
+  - q1
+alphabet:
+  - a
+  - b
+delta:
+  q0:
+    a:
+      - q0
+    ε:
+      - q1
+  q1:
+    b:
+      - q1
+start:
+  - q0
+accepting:
+  - q1
+`, string(data))
+}
+
+func TestParseErrors(t *testing.T) {
+	var tests = []struct {
+		definition string
+		want       error
+	}{
+		{
+			"type: pda",
+			fmt.Errorf("the type 'pda' must be either 'dfa' or 'nfa'"),
+		},
+		{
+			"{type: dfa, states: [q0], alphabet: [ab], delta: {q0: {ab: q0}}, start: q0, accepting: []}",
+			fmt.Errorf("the symbol 'ab' must be exactly one character"),
+		},
+		{
+			"{type: dfa, states: [q0], alphabet: [a], delta: {q0: {}}, start: q0, accepting: []}",
+			fmt.Errorf("delta is not defined for the state 'q0' and the symbol 'a'"),
+		},
+		{
+			"{type: nfa, states: [q0], alphabet: [a], delta: {q0: {a: [q1]}}, start: [q0], accepting: []}",
+			fmt.Errorf("the new state 'q1' is not within the possible states"),
+		},
+		{
+			"{type: nfa, states: [q0], alphabet: [a], delta: {}, start: [q0], accepting: []}",
+			fmt.Errorf("delta is not defined for the state 'q0' and the symbol 'a'"),
+		},
+		{
+			"{type: nfa, states: [q0], alphabet: [a], delta: {q0: {}}, start: [q0], accepting: [q2]}",
+			fmt.Errorf("the accepting state 'q2' is not within the possible states"),
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse([]byte(tt.definition), YAML)
+
+		assert.Equal(t, tt.want, err, tt.definition)
+	}
+
+	_, err := Parse([]byte("{type: dfa, final: [q0]}"), YAML)
+	assert.Error(t, err)
+
+	_, err = Parse([]byte(`{"type": "dfa", "final": ["q0"]}`), JSON)
+	assert.Error(t, err)
+
+	_, err = Load("machine.txt")
+	assert.Equal(t, fmt.Errorf("the file 'machine.txt' must have the extension '.json', '.yaml' or '.yml'"), err)
+}
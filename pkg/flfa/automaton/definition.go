@@ -0,0 +1,390 @@
+package automaton
+
+import (
+	"bytes"
+	"encoding/json"
+	"flfa/dfa"
+	"flfa/nfa"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Format string
+
+const (
+	JSON Format = "json"
+	YAML Format = "yaml"
+)
+
+type header struct {
+	Type Kind `json:"type" yaml:"type"`
+}
+
+/*
+	The file representation of a DFA.
+*/
+type DFADefinition struct {
+	Type      Kind                         `json:"type" yaml:"type"`
+	States    []string                     `json:"states" yaml:"states"`
+	Alphabet  []string                     `json:"alphabet" yaml:"alphabet"`
+	Delta     map[string]map[string]string `json:"delta" yaml:"delta"`
+	Start     string                       `json:"start" yaml:"start"`
+	Accepting []string                     `json:"accepting" yaml:"accepting"`
+}
+
+/*
+	The file representation of an NFA, where target states are lists of state names instead of bit maps.
+*/
+type NFADefinition struct {
+	Type      Kind                           `json:"type" yaml:"type"`
+	States    []string                       `json:"states" yaml:"states"`
+	Alphabet  []string                       `json:"alphabet" yaml:"alphabet"`
+	Delta     map[string]map[string][]string `json:"delta" yaml:"delta"`
+	Start     []string                       `json:"start" yaml:"start"`
+	Accepting []string                       `json:"accepting" yaml:"accepting"`
+}
+
+/*
+	Finds the format of a definition file from its extension.
+*/
+func FormatOf(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return JSON, nil
+	case ".yaml", ".yml":
+		return YAML, nil
+	}
+
+	return "", fmt.Errorf("the file '%v' must have the extension '.json', '.yaml' or '.yml'", path)
+}
+
+/*
+	Loads and validates a DFA or NFA from a definition file.
+*/
+func Load(path string) (Automaton, error) {
+	format, err := FormatOf(path)
+	if err != nil {
+		return Automaton{}, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Automaton{}, err
+	}
+
+	automaton, err := Parse(data, format)
+	if err != nil {
+		return Automaton{}, fmt.Errorf("%v: %v", path, err)
+	}
+
+	return automaton, nil
+}
+
+/*
+	Parses and validates a DFA or NFA from a definition.
+	Unknown fields are reported as errors.
+*/
+func Parse(data []byte, format Format) (Automaton, error) {
+	var h header
+	err := unmarshal(data, format, &h, false)
+	if err != nil {
+		return Automaton{}, err
+	}
+
+	switch h.Type {
+	case DFA:
+		var definition DFADefinition
+		err := unmarshal(data, format, &definition, true)
+		if err != nil {
+			return Automaton{}, err
+		}
+
+		d, err := definition.ToDFA()
+		if err != nil {
+			return Automaton{}, err
+		}
+
+		return Automaton{Kind: DFA, DFA: d}, nil
+	case NFA:
+		var definition NFADefinition
+		err := unmarshal(data, format, &definition, true)
+		if err != nil {
+			return Automaton{}, err
+		}
+
+		n, err := definition.ToNFA()
+		if err != nil {
+			return Automaton{}, err
+		}
+
+		return Automaton{Kind: NFA, NFA: n}, nil
+	}
+
+	return Automaton{}, fmt.Errorf("the type '%v' must be either '%v' or '%v'", h.Type, DFA, NFA)
+}
+
+/*
+	Writes an automaton as a definition in the given format.
+*/
+func Marshal(automaton Automaton, format Format) ([]byte, error) {
+	if automaton.Kind == NFA {
+		return marshal(NewNFADefinition(automaton.NFA), format)
+	}
+
+	return marshal(NewDFADefinition(automaton.DFA), format)
+}
+
+/*
+	Creates the definition of a DFA.
+*/
+func NewDFADefinition(d dfa.DFA) DFADefinition {
+	definition := DFADefinition{
+		Type:      DFA,
+		Start:     string(d.StartingState()),
+		Delta:     make(map[string]map[string]string),
+		Accepting: []string{},
+	}
+
+	for _, state := range d.States() {
+		definition.States = append(definition.States, string(state))
+		definition.Delta[string(state)] = make(map[string]string)
+
+		for _, symbol := range d.Alphabet() {
+			definition.Delta[string(state)][string(symbol)] = string(d.Transition(state, symbol))
+		}
+	}
+
+	for _, symbol := range d.Alphabet() {
+		definition.Alphabet = append(definition.Alphabet, string(symbol))
+	}
+
+	for _, state := range d.AcceptingStates() {
+		definition.Accepting = append(definition.Accepting, string(state))
+	}
+
+	return definition
+}
+
+/*
+	Creates the definition of an NFA.
+	Symbols without any target states are left out of delta.
+*/
+func NewNFADefinition(n nfa.NFA) NFADefinition {
+	definition := NFADefinition{
+		Type:      NFA,
+		Delta:     make(map[string]map[string][]string),
+		Start:     stateNames(n, n.StartingStates()),
+		Accepting: stateNames(n, n.AcceptingStates()),
+	}
+
+	symbols := append(n.Alphabet(), nfa.Epsilon)
+
+	for _, state := range n.States() {
+		definition.States = append(definition.States, string(state))
+		definition.Delta[string(state)] = make(map[string][]string)
+
+		for _, symbol := range symbols {
+			if newStates := n.Transition(state, symbol); !newStates.IsEmpty() {
+				definition.Delta[string(state)][string(symbol)] = stateNames(n, newStates)
+			}
+		}
+	}
+
+	for _, symbol := range n.Alphabet() {
+		definition.Alphabet = append(definition.Alphabet, string(symbol))
+	}
+
+	return definition
+}
+
+/*
+	Creates and validates the DFA of a definition.
+*/
+func (definition *DFADefinition) ToDFA() (dfa.DFA, error) {
+	var states []dfa.State
+	for _, state := range definition.States {
+		states = append(states, dfa.State(state))
+	}
+
+	var alphabet []dfa.Symbol
+	for _, str := range definition.Alphabet {
+		symbol, err := parseSymbol(str)
+		if err != nil {
+			return dfa.DFA{}, err
+		}
+
+		alphabet = append(alphabet, dfa.Symbol(symbol))
+	}
+
+	delta := make(dfa.Delta, len(definition.Delta))
+	for state, transitions := range definition.Delta {
+		delta[dfa.State(state)] = make(map[dfa.Symbol]dfa.State, len(transitions))
+
+		for str, newState := range transitions {
+			symbol, err := parseSymbol(str)
+			if err != nil {
+				return dfa.DFA{}, err
+			}
+
+			delta[dfa.State(state)][dfa.Symbol(symbol)] = dfa.State(newState)
+		}
+	}
+
+	var acceptingStates []dfa.State
+	for _, state := range definition.Accepting {
+		acceptingStates = append(acceptingStates, dfa.State(state))
+	}
+
+	return dfa.NewDFA(states, alphabet, delta, dfa.State(definition.Start), acceptingStates)
+}
+
+/*
+	Creates and validates the NFA of a definition.
+	Symbols left out of delta move to no states.
+*/
+func (definition *NFADefinition) ToNFA() (nfa.NFA, error) {
+	var states []nfa.State
+	stateIndices := make(map[string]int, len(definition.States))
+	for i, state := range definition.States {
+		states = append(states, nfa.State(state))
+		stateIndices[state] = i
+	}
+
+	var alphabet []nfa.Symbol
+	for _, str := range definition.Alphabet {
+		symbol, err := parseSymbol(str)
+		if err != nil {
+			return nfa.NFA{}, err
+		}
+
+		alphabet = append(alphabet, nfa.Symbol(symbol))
+	}
+
+	stateSet := func(names []string, str string) (nfa.StateSet, error) {
+		set := nfa.StateSet{}
+
+		for _, name := range names {
+			i, ok := stateIndices[name]
+			if !ok {
+				return nil, fmt.Errorf("the %v state '%v' is not within the possible states", str, name)
+			}
+
+			set.Add(i)
+		}
+
+		return set, nil
+	}
+
+	delta := make(nfa.SetDelta, len(definition.Delta))
+	for state, transitions := range definition.Delta {
+		delta[nfa.State(state)] = make(map[nfa.Symbol]nfa.StateSet, len(alphabet))
+
+		for str, names := range transitions {
+			symbol, err := parseSymbol(str)
+			if err != nil {
+				return nfa.NFA{}, err
+			}
+
+			delta[nfa.State(state)][nfa.Symbol(symbol)], err = stateSet(names, "new")
+			if err != nil {
+				return nfa.NFA{}, err
+			}
+		}
+	}
+
+	// Fills in the symbols left out of delta for the states that are defined
+	for _, state := range states {
+		if _, ok := delta[state]; !ok {
+			continue
+		}
+
+		for _, symbol := range alphabet {
+			if _, ok := delta[state][symbol]; !ok {
+				delta[state][symbol] = nfa.StateSet{}
+			}
+		}
+	}
+
+	startingStates, err := stateSet(definition.Start, "starting")
+	if err != nil {
+		return nfa.NFA{}, err
+	}
+
+	acceptingStates, err := stateSet(definition.Accepting, "accepting")
+	if err != nil {
+		return nfa.NFA{}, err
+	}
+
+	return nfa.NewNFAFromSets(states, alphabet, delta, startingStates, acceptingStates)
+}
+
+/*
+	Decodes a state set into a list of state names.
+*/
+func stateNames(n nfa.NFA, stateSet nfa.StateSet) []string {
+	names := []string{}
+	for _, state := range n.StateNames(stateSet) {
+		names = append(names, string(state))
+	}
+
+	return names
+}
+
+/*
+	Decodes a definition, optionally reporting unknown fields.
+*/
+func unmarshal(data []byte, format Format, v interface{}, isStrict bool) error {
+	switch format {
+	case JSON:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		if isStrict {
+			decoder.DisallowUnknownFields()
+		}
+
+		return decoder.Decode(v)
+	case YAML:
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(isStrict)
+
+		return decoder.Decode(v)
+	}
+
+	return fmt.Errorf("the format '%v' must be either '%v' or '%v'", format, JSON, YAML)
+}
+
+/*
+	Encodes a definition.
+*/
+func marshal(v interface{}, format Format) ([]byte, error) {
+	switch format {
+	case JSON:
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		return append(data, '\n'), nil
+	case YAML:
+		var buffer bytes.Buffer
+		encoder := yaml.NewEncoder(&buffer)
+		encoder.SetIndent(2)
+
+		err := encoder.Encode(v)
+		if err != nil {
+			return nil, err
+		}
+
+		err = encoder.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		return buffer.Bytes(), nil
+	}
+
+	return nil, fmt.Errorf("the format '%v' must be either '%v' or '%v'", format, JSON, YAML)
+}
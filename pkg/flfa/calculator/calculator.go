@@ -0,0 +1,168 @@
+package calculator
+
+import (
+	"flfa/grammar"
+	"flfa/lexer"
+	"flfa/ll1"
+	"flfa/parsetree"
+	"fmt"
+	"strconv"
+)
+
+/*
+	Evaluates an arithmetic expression with floating point numbers, '+', '-', '*', '/', parentheses and unary minus.
+	The binary operators are left associative, and '*' and '/' take precedence over '+' and '-'.
+	Dividing by zero is an error.
+*/
+func Evaluate(str string) (float64, error) {
+	parser, err := newParser()
+	if err != nil {
+		return 0, err
+	}
+
+	tree, err := parser.Parse(str)
+	if err != nil {
+		return 0, err
+	}
+
+	return evaluator{str}.expression(tree)
+}
+
+/*
+	Finds every syntax error in an arithmetic expression in one pass.
+	No syntax errors are returned for a well formed expression, even if it divides by zero.
+*/
+func Diagnose(str string) ([]*ll1.SyntaxError, error) {
+	parser, err := newParser()
+	if err != nil {
+		return nil, err
+	}
+
+	_, syntaxErrors, err := parser.ParseRecover(str)
+
+	return syntaxErrors, err
+}
+
+/*
+	Creates the LL(1) parser of arithmetic expressions.
+	The lists of operators are right recursive to keep the grammar LL(1), and the evaluator folds them to the left.
+*/
+func newParser() (ll1.LL1, error) {
+	g, err := grammar.NewGrammar(
+		[]grammar.Symbol{"Expr", "ExprTail", "Term", "TermTail", "Factor"},
+		[]grammar.Symbol{"number", "+", "-", "*", "/", "(", ")"},
+		"Expr",
+		[]grammar.Production{
+			{Head: "Expr", Body: []grammar.Symbol{"Term", "ExprTail"}},
+			{Head: "ExprTail", Body: []grammar.Symbol{"+", "Term", "ExprTail"}},
+			{Head: "ExprTail", Body: []grammar.Symbol{"-", "Term", "ExprTail"}},
+			{Head: "ExprTail", Body: []grammar.Symbol{}},
+			{Head: "Term", Body: []grammar.Symbol{"Factor", "TermTail"}},
+			{Head: "TermTail", Body: []grammar.Symbol{"*", "Factor", "TermTail"}},
+			{Head: "TermTail", Body: []grammar.Symbol{"/", "Factor", "TermTail"}},
+			{Head: "TermTail", Body: []grammar.Symbol{}},
+			{Head: "Factor", Body: []grammar.Symbol{"(", "Expr", ")"}},
+			{Head: "Factor", Body: []grammar.Symbol{"-", "Factor"}},
+			{Head: "Factor", Body: []grammar.Symbol{"number"}},
+		},
+	)
+	if err != nil {
+		return ll1.LL1{}, err
+	}
+
+	lex, err := lexer.NewLexer([]lexer.Rule{
+		{Kind: "number", Regex: "\\d+\\.?\\d*|\\.\\d+"},
+		{Kind: "+", Regex: "\\+"},
+		{Kind: "-", Regex: "-"},
+		{Kind: "*", Regex: "\\*"},
+		{Kind: "/", Regex: "/"},
+		{Kind: "(", Regex: "\\("},
+		{Kind: ")", Regex: "\\)"},
+		{Regex: "\\s+", Skip: true},
+	})
+	if err != nil {
+		return ll1.LL1{}, err
+	}
+
+	return ll1.NewLL1FromGrammarWithLexer(g, lex)
+}
+
+/*
+	Evaluates the nodes of a parse tree, keeping the string to point at errors.
+*/
+type evaluator struct {
+	str string
+}
+
+/*
+	Evaluates Expr → Term ExprTail.
+*/
+func (evaluator evaluator) expression(node *parsetree.Node) (float64, error) {
+	value, err := evaluator.term(node.Children[0])
+	if err != nil {
+		return 0, err
+	}
+
+	// Walks ExprTail → + Term ExprTail | - Term ExprTail | ε, applying every operator to the value so far
+	for tail := node.Children[1]; len(tail.Children) != 0; tail = tail.Children[2] {
+		right, err := evaluator.term(tail.Children[1])
+		if err != nil {
+			return 0, err
+		}
+
+		if tail.Children[0].Symbol == "+" {
+			value += right
+		} else {
+			value -= right
+		}
+	}
+
+	return value, nil
+}
+
+/*
+	Evaluates Term → Factor TermTail.
+*/
+func (evaluator evaluator) term(node *parsetree.Node) (float64, error) {
+	value, err := evaluator.factor(node.Children[0])
+	if err != nil {
+		return 0, err
+	}
+
+	// Walks TermTail → * Factor TermTail | / Factor TermTail | ε, applying every operator to the value so far
+	for tail := node.Children[1]; len(tail.Children) != 0; tail = tail.Children[2] {
+		right, err := evaluator.factor(tail.Children[1])
+		if err != nil {
+			return 0, err
+		}
+
+		if tail.Children[0].Symbol == "*" {
+			value *= right
+			continue
+		}
+
+		if right == 0 {
+			start := tail.Children[0].Span.Start
+			return 0, fmt.Errorf("division by zero at the position '%v ~error~ %v'", evaluator.str[:start], evaluator.str[start:])
+		}
+
+		value /= right
+	}
+
+	return value, nil
+}
+
+/*
+	Evaluates Factor → ( Expr ) | - Factor | number.
+*/
+func (evaluator evaluator) factor(node *parsetree.Node) (float64, error) {
+	switch node.Children[0].Symbol {
+	case "(":
+		return evaluator.expression(node.Children[1])
+	case "-":
+		value, err := evaluator.factor(node.Children[1])
+		return -value, err
+	}
+
+	return strconv.ParseFloat(node.Children[0].Lexeme, 64)
+}
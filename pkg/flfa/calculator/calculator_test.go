@@ -0,0 +1,99 @@
+package calculator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	var tests = []struct {
+		str  string
+		want float64
+	}{
+		{"3+4*(2-1)", 7},
+		{"2 * 3 + 4", 10},
+		{"2 + 3 * 4", 14},
+		{"10 - 4 - 3", 3},
+		{"64 / 4 / 2", 8},
+		{"1.5 + .25 + 2.", 3.75},
+		{"-3", -3},
+		{"--3", 3},
+		{"2 * -(1 + 2)", -6},
+		{"1 - -1", 2},
+		{"-2 * 3 - 4", -10},
+		{"((7))", 7},
+	}
+
+	for _, tt := range tests {
+		value, err := Evaluate(tt.str)
+
+		assert.Equal(t, nil, err, "string '%v'", tt.str)
+		assert.Equal(t, tt.want, value, "string '%v'", tt.str)
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	var tests = []struct {
+		str  string
+		want error
+	}{
+		{"1 / 0", fmt.Errorf("division by zero at the position '1  ~error~ / 0'")},
+		{"1 / (2 - 2)", fmt.Errorf("division by zero at the position '1  ~error~ / (2 - 2)'")},
+		{"1 + x", fmt.Errorf("the character 'x' at 1:5 does not start any token")},
+	}
+
+	for _, tt := range tests {
+		_, err := Evaluate(tt.str)
+
+		assert.Equal(t, tt.want, err, "string '%v'", tt.str)
+	}
+
+	_, err := Evaluate("1 +")
+	assert.Error(t, err)
+
+	_, err = Evaluate("(1")
+	assert.Error(t, err)
+}
+
+func TestDiagnose(t *testing.T) {
+	var tests = []struct {
+		str  string
+		want []string
+	}{
+		{"3+4*(2-1)", nil},
+		{"1 / 0", nil},
+		{"3 + * 4", []string{"1:5: unexpected '*' while parsing 'Term', expected '(' or '-' or 'number'"}},
+		{"(1 + 2", []string{"1:7: unexpected end of the string while parsing 'Factor', expected ')'"}},
+		{
+			"* 4 4",
+			[]string{
+				"1:1: unexpected '*' while parsing 'Expr', expected '(' or '-' or 'number'",
+				"1:5: unexpected '4' while parsing 'TermTail', expected ')' or '*' or '+' or '-' or '/' or end of the string",
+			},
+		},
+		{
+			"1 + ) 2 * (3 -",
+			[]string{
+				"1:5: unexpected ')' while parsing 'Term', expected '(' or '-' or 'number'",
+				"1:15: unexpected end of the string while parsing 'Term', expected '(' or '-' or 'number'",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		syntaxErrors, err := Diagnose(tt.str)
+		assert.Equal(t, nil, err, "string '%v'", tt.str)
+
+		var messages []string
+		for _, syntaxError := range syntaxErrors {
+			messages = append(messages, syntaxError.Error())
+		}
+
+		assert.Equal(t, tt.want, messages, "string '%v'", tt.str)
+	}
+
+	_, err := Diagnose("1 $ 2")
+	assert.Equal(t, fmt.Errorf("the character '$' at 1:3 does not start any token"), err)
+}
@@ -0,0 +1,261 @@
+package cfg
+
+import (
+	"flfa/grammar"
+	"flfa/internal/sliceutil"
+	"fmt"
+	"strconv"
+)
+
+/*
+	A step of the conversion into Chomsky normal form and the grammar after it.
+*/
+type Step struct {
+	Name    string
+	Grammar grammar.Grammar
+}
+
+/*
+	Converts a grammar into Chomsky normal form, where every production is 'A -> B C' or 'A -> a',
+	and only the start symbol may derive ε, in which case it is in no body.
+	The steps START, TERM, BIN, DEL and UNIT are applied in that order, and the grammar after every step is returned with the result.
+	Useless symbols are kept, which RemoveUseless of the grammar package can remove from the result.
+*/
+func ToCNF(g grammar.Grammar) (grammar.Grammar, []Step, error) {
+	var steps []Step
+
+	for _, step := range []struct {
+		name string
+		run  func(grammar.Grammar) (grammar.Grammar, error)
+	}{
+		{"START", Start},
+		{"TERM", Term},
+		{"BIN", Bin},
+		{"DEL", Del},
+		{"UNIT", Unit},
+	} {
+		var err error
+		g, err = step.run(g)
+		if err != nil {
+			return grammar.Grammar{}, nil, err
+		}
+
+		steps = append(steps, Step{step.name, g})
+	}
+
+	return g, steps, nil
+}
+
+/*
+	Checks that a grammar is in Chomsky normal form.
+*/
+func ValidateCNF(g grammar.Grammar) error {
+	isStartInBody := false
+	for _, production := range g.Productions() {
+		for _, symbol := range production.Body {
+			if symbol == g.Start() {
+				isStartInBody = true
+			}
+		}
+	}
+
+	for _, production := range g.Productions() {
+		switch len(production.Body) {
+		case 0:
+			if production.Head == g.Start() && !isStartInBody {
+				continue
+			}
+		case 1:
+			if g.IsTerminal(production.Body[0]) {
+				continue
+			}
+		case 2:
+			if g.IsNonterminal(production.Body[0]) && g.IsNonterminal(production.Body[1]) {
+				continue
+			}
+		}
+
+		return fmt.Errorf("the production '%v' is not in Chomsky normal form", production)
+	}
+
+	return nil
+}
+
+/*
+	The START step, which adds a new start symbol deriving the old one, so that the start symbol is in no body.
+*/
+func Start(g grammar.Grammar) (grammar.Grammar, error) {
+	start := fresh(string(g.Start())+"'", g)
+
+	nonterminals := append([]grammar.Symbol{start}, g.Nonterminals()...)
+	productions := append([]grammar.Production{{Head: start, Body: []grammar.Symbol{g.Start()}}}, g.Productions()...)
+
+	return grammar.NewGrammar(nonterminals, g.Terminals(), start, productions)
+}
+
+/*
+	The TERM step, which replaces every terminal 'a' in a body of more than one symbol with a new nonterminal 'T_a' deriving it.
+*/
+func Term(g grammar.Grammar) (grammar.Grammar, error) {
+	nonterminals := g.Nonterminals()
+	replacements := make(map[grammar.Symbol]grammar.Symbol)
+
+	var productions []grammar.Production
+	var terminalProductions []grammar.Production
+
+	for _, production := range g.Productions() {
+		if len(production.Body) < 2 {
+			productions = append(productions, production)
+			continue
+		}
+
+		body := make([]grammar.Symbol, len(production.Body))
+		for i, symbol := range production.Body {
+			body[i] = symbol
+
+			if !g.IsTerminal(symbol) {
+				continue
+			}
+
+			replacement, ok := replacements[symbol]
+			if !ok {
+				replacement = fresh("T_"+string(symbol), g, nonterminals...)
+				replacements[symbol] = replacement
+
+				nonterminals = append(nonterminals, replacement)
+				terminalProductions = append(terminalProductions, grammar.Production{Head: replacement, Body: []grammar.Symbol{symbol}})
+			}
+
+			body[i] = replacement
+		}
+
+		productions = append(productions, grammar.Production{Head: production.Head, Body: body})
+	}
+
+	return grammar.NewGrammar(nonterminals, g.Terminals(), g.Start(), append(productions, terminalProductions...))
+}
+
+/*
+	The BIN step, which splits every body of more than two symbols into a chain of productions of two symbols.
+	The production 'A -> X Y Z' becomes 'A -> X A_1' and 'A_1 -> Y Z'.
+*/
+func Bin(g grammar.Grammar) (grammar.Grammar, error) {
+	nonterminals := g.Nonterminals()
+	counts := make(map[grammar.Symbol]int)
+
+	// The nonterminal the next chain nonterminal of every head is inserted after
+	lasts := make(map[grammar.Symbol]grammar.Symbol)
+
+	var productions []grammar.Production
+
+	for _, production := range g.Productions() {
+		head := production.Head
+		body := production.Body
+
+		for len(body) > 2 {
+			counts[production.Head]++
+			next := fresh(string(production.Head)+"_"+strconv.Itoa(counts[production.Head]), g, nonterminals...)
+
+			last, ok := lasts[production.Head]
+			if !ok {
+				last = production.Head
+			}
+
+			nonterminals = sliceutil.InsertAfter(nonterminals, last, next)
+			lasts[production.Head] = next
+
+			productions = append(productions, grammar.Production{Head: head, Body: []grammar.Symbol{body[0], next}})
+
+			head = next
+			body = body[1:]
+		}
+
+		productions = append(productions, grammar.Production{Head: head, Body: body})
+	}
+
+	return grammar.NewGrammar(nonterminals, g.Terminals(), g.Start(), productions)
+}
+
+/*
+	The DEL step, which removes the ε productions.
+	Every production is replaced with the productions of every way to drop nullable symbols from its body,
+	except that no ε productions are added, other than 'S -> ε' for a nullable start symbol S.
+*/
+func Del(g grammar.Grammar) (grammar.Grammar, error) {
+	nullable := g.Nullable()
+
+	var productions []grammar.Production
+
+	for _, production := range g.Productions() {
+		bodies := [][]grammar.Symbol{{}}
+
+		for _, symbol := range production.Body {
+			var next [][]grammar.Symbol
+			for _, body := range bodies {
+				next = append(next, append(append([]grammar.Symbol{}, body...), symbol))
+
+				if nullable[symbol] {
+					next = append(next, body)
+				}
+			}
+
+			bodies = next
+		}
+
+		for _, body := range bodies {
+			if len(body) != 0 || production.Head == g.Start() {
+				productions = sliceutil.AppendUnique(productions, grammar.Production{Head: production.Head, Body: body})
+			}
+		}
+	}
+
+	return grammar.NewGrammar(g.Nonterminals(), g.Terminals(), g.Start(), productions)
+}
+
+/*
+	The UNIT step, which removes the productions whose body is a single nonterminal.
+	Every nonterminal gets the other productions of the nonterminals it derives through unit productions.
+*/
+func Unit(g grammar.Grammar) (grammar.Grammar, error) {
+	var productions []grammar.Production
+
+	for _, nonterminal := range g.Nonterminals() {
+		// The nonterminals reachable through unit productions, in the order they are found
+		reachable := []grammar.Symbol{nonterminal}
+
+		for i := 0; i < len(reachable); i++ {
+			for _, production := range g.ProductionsOf(reachable[i]) {
+				if isUnit(g, production) {
+					if !sliceutil.Contains(reachable, production.Body[0]) {
+						reachable = append(reachable, production.Body[0])
+					}
+
+					continue
+				}
+
+				productions = sliceutil.AppendUnique(productions, grammar.Production{Head: nonterminal, Body: production.Body})
+			}
+		}
+	}
+
+	return grammar.NewGrammar(g.Nonterminals(), g.Terminals(), g.Start(), productions)
+}
+
+/*
+	Checks if the body of a production is a single nonterminal.
+*/
+func isUnit(g grammar.Grammar, production grammar.Production) bool {
+	return len(production.Body) == 1 && g.IsNonterminal(production.Body[0])
+}
+
+/*
+	Creates a nonterminal with the given name, with primes added until the name is unused by the grammar and the other symbols.
+*/
+func fresh(name string, g grammar.Grammar, symbols ...grammar.Symbol) grammar.Symbol {
+	symbol := grammar.Symbol(name)
+	for g.IsNonterminal(symbol) || g.IsTerminal(symbol) || sliceutil.Contains(symbols, symbol) {
+		symbol += "'"
+	}
+
+	return symbol
+}
@@ -0,0 +1,182 @@
+package cfg
+
+import (
+	"flfa/grammar"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/*
+	Creates a grammar that needs every step of the conversion into Chomsky normal form.
+*/
+func newUnnormalizedGrammar(t *testing.T) grammar.Grammar {
+	t.Helper()
+
+	g, err := grammar.NewGrammar(
+		[]grammar.Symbol{"S", "A", "B"},
+		[]grammar.Symbol{"a", "b"},
+		"S",
+		[]grammar.Production{
+			{Head: "S", Body: []grammar.Symbol{"A", "S", "A"}},
+			{Head: "S", Body: []grammar.Symbol{"a", "B"}},
+			{Head: "A", Body: []grammar.Symbol{"B"}},
+			{Head: "A", Body: []grammar.Symbol{"S"}},
+			{Head: "B", Body: []grammar.Symbol{"b"}},
+			{Head: "B", Body: []grammar.Symbol{}},
+		},
+	)
+	require.NoError(t, err)
+
+	return g
+}
+
+func TestToCNF(t *testing.T) {
+	g, steps, err := ToCNF(newUnnormalizedGrammar(t))
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, ValidateCNF(g))
+	assert.Equal(t, []grammar.Symbol{"S'", "S", "S_1", "A", "B", "T_a"}, g.Nonterminals())
+	assert.Equal(t, `S' -> A S_1
+S' -> T_a B
+S' -> S A
+S' -> a
+S -> A S_1
+S -> T_a B
+S -> S A
+S -> a
+S_1 -> S A
+S_1 -> A S_1
+S_1 -> T_a B
+S_1 -> a
+A -> b
+A -> A S_1
+A -> T_a B
+A -> S A
+A -> a
+B -> b
+T_a -> a
+`, g.String())
+
+	var names []string
+	for _, step := range steps {
+		names = append(names, step.Name)
+	}
+
+	assert.Equal(t, []string{"START", "TERM", "BIN", "DEL", "UNIT"}, names)
+	assert.Equal(t, g, steps[len(steps)-1].Grammar)
+}
+
+func TestSteps(t *testing.T) {
+	g, err := Start(newUnnormalizedGrammar(t))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, grammar.Symbol("S'"), g.Start())
+	assert.Equal(t, grammar.Production{Head: "S'", Body: []grammar.Symbol{"S"}}, g.Productions()[0])
+
+	g, err = Term(g)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `S' -> S
+S -> A S A
+S -> T_a B
+A -> B
+A -> S
+B -> b
+B -> ε
+T_a -> a
+`, g.String())
+
+	g, err = Bin(g)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `S' -> S
+S -> A S_1
+S_1 -> S A
+S -> T_a B
+A -> B
+A -> S
+B -> b
+B -> ε
+T_a -> a
+`, g.String())
+
+	g, err = Del(g)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `S' -> S
+S -> A S_1
+S -> S_1
+S_1 -> S A
+S_1 -> S
+S -> T_a B
+S -> T_a
+A -> B
+A -> S
+B -> b
+T_a -> a
+`, g.String())
+}
+
+func TestStepsNames(t *testing.T) {
+	// New nonterminals get primes when their names are taken
+	g, err := grammar.NewGrammar(
+		[]grammar.Symbol{"S", "S'", "S_1", "T_a"},
+		[]grammar.Symbol{"a"},
+		"S",
+		[]grammar.Production{
+			{Head: "S", Body: []grammar.Symbol{"a", "a", "S_1", "S'", "T_a"}},
+			{Head: "S'", Body: []grammar.Symbol{"a"}},
+			{Head: "S_1", Body: []grammar.Symbol{"a"}},
+			{Head: "T_a", Body: []grammar.Symbol{"a"}},
+		},
+	)
+	assert.Equal(t, nil, err)
+
+	g, _, err = ToCNF(g)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []grammar.Symbol{"S''", "S", "S_1'", "S_2", "S_3", "S'", "S_1", "T_a", "T_a'"}, g.Nonterminals())
+	assert.Equal(t, nil, ValidateCNF(g))
+}
+
+func TestDelStart(t *testing.T) {
+	// Only the start symbol keeps its ε production
+	g, err := grammar.NewGrammar(
+		[]grammar.Symbol{"S", "A"},
+		[]grammar.Symbol{"a"},
+		"S",
+		[]grammar.Production{
+			{Head: "S", Body: []grammar.Symbol{"A", "A"}},
+			{Head: "A", Body: []grammar.Symbol{"a"}},
+			{Head: "A", Body: []grammar.Symbol{}},
+		},
+	)
+	assert.Equal(t, nil, err)
+
+	g, err = Del(g)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `S -> A A
+S -> A
+S -> ε
+A -> a
+`, g.String())
+}
+
+func TestValidateCNF(t *testing.T) {
+	var tests = []struct {
+		productions []grammar.Production
+		want        error
+	}{
+		{[]grammar.Production{{Head: "S", Body: []grammar.Symbol{"A", "A"}}, {Head: "A", Body: []grammar.Symbol{"a"}}, {Head: "S", Body: []grammar.Symbol{}}}, nil},
+		{[]grammar.Production{{Head: "S", Body: []grammar.Symbol{"A"}}}, fmt.Errorf("the production 'S -> A' is not in Chomsky normal form")},
+		{[]grammar.Production{{Head: "S", Body: []grammar.Symbol{"A", "a"}}}, fmt.Errorf("the production 'S -> A a' is not in Chomsky normal form")},
+		{[]grammar.Production{{Head: "S", Body: []grammar.Symbol{"A", "A", "A"}}}, fmt.Errorf("the production 'S -> A A A' is not in Chomsky normal form")},
+		{[]grammar.Production{{Head: "A", Body: []grammar.Symbol{}}}, fmt.Errorf("the production 'A -> ε' is not in Chomsky normal form")},
+		{[]grammar.Production{{Head: "S", Body: []grammar.Symbol{}}, {Head: "A", Body: []grammar.Symbol{"S", "S"}}}, fmt.Errorf("the production 'S -> ε' is not in Chomsky normal form")},
+	}
+
+	for _, tt := range tests {
+		g, err := grammar.NewGrammar([]grammar.Symbol{"S", "A"}, []grammar.Symbol{"a"}, "S", tt.productions)
+		assert.Equal(t, nil, err)
+
+		assert.Equal(t, tt.want, ValidateCNF(g), "grammar '%v'", g)
+	}
+}
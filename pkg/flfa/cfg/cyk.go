@@ -0,0 +1,175 @@
+package cfg
+
+import (
+	"flfa/grammar"
+	"flfa/lexer"
+	"flfa/parsetree"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+/*
+	The triangular CYK table of a string.
+	The cell Cells[length-1][start] holds the nonterminals deriving the length tokens from the start token on.
+*/
+type Table struct {
+	Tokens []lexer.Token
+	Cells  [][]grammar.SymbolSet
+}
+
+/*
+	The result of the CYK algorithm.
+	When the string is accepted, the parse tree and the leftmost derivation of the string are given with the table.
+*/
+type Result struct {
+	Table      Table
+	IsAccepted bool
+	Tree       *parsetree.Node
+	Derivation []grammar.Production
+}
+
+/*
+	The production a nonterminal was added to a cell with, and the length of the first part of the tokens if it is binary.
+*/
+type backPointer struct {
+	production grammar.Production
+	split      int
+}
+
+/*
+	Runs the CYK algorithm of a grammar in Chomsky normal form on tokens, which lexer.Runes gives for the characters of a string.
+	Every token is read as the terminal named by its kind.
+*/
+func CYK(g grammar.Grammar, tokens []lexer.Token) (Result, error) {
+	err := ValidateCNF(g)
+	if err != nil {
+		return Result{}, err
+	}
+
+	n := len(tokens)
+
+	table := Table{tokens, make([][]grammar.SymbolSet, n)}
+	backPointers := make([][]map[grammar.Symbol]backPointer, n)
+
+	for length := 1; length <= n; length++ {
+		table.Cells[length-1] = make([]grammar.SymbolSet, n-length+1)
+		backPointers[length-1] = make([]map[grammar.Symbol]backPointer, n-length+1)
+
+		for start := 0; start+length <= n; start++ {
+			cell := make(grammar.SymbolSet)
+			cellBackPointers := make(map[grammar.Symbol]backPointer)
+
+			for _, production := range g.Productions() {
+				if cell[production.Head] {
+					continue
+				}
+
+				if length == 1 && len(production.Body) == 1 && production.Body[0] == grammar.Symbol(tokens[start].Kind) {
+					cell[production.Head] = true
+					cellBackPointers[production.Head] = backPointer{production, 0}
+					continue
+				}
+
+				if len(production.Body) != 2 {
+					continue
+				}
+
+				for split := 1; split < length; split++ {
+					if table.Cells[split-1][start][production.Body[0]] && table.Cells[length-split-1][start+split][production.Body[1]] {
+						cell[production.Head] = true
+						cellBackPointers[production.Head] = backPointer{production, split}
+						break
+					}
+				}
+			}
+
+			table.Cells[length-1][start] = cell
+			backPointers[length-1][start] = cellBackPointers
+		}
+	}
+
+	if n == 0 {
+		for _, production := range g.ProductionsOf(g.Start()) {
+			if len(production.Body) == 0 {
+				return Result{table, true, &parsetree.Node{Symbol: string(g.Start())}, []grammar.Production{production}}, nil
+			}
+		}
+
+		return Result{table, false, nil, nil}, nil
+	}
+
+	if !table.Cells[n-1][0][g.Start()] {
+		return Result{table, false, nil, nil}, nil
+	}
+
+	var derivation []grammar.Production
+	tree := build(g.Start(), 0, n, tokens, backPointers, &derivation)
+	tree.FitSpans()
+
+	return Result{table, true, tree, derivation}, nil
+}
+
+/*
+	Builds the parse tree of a nonterminal over the tokens from start up to end from the back pointers,
+	appending the productions it expands with in leftmost order.
+*/
+func build(nonterminal grammar.Symbol, start int, end int, tokens []lexer.Token, backPointers [][]map[grammar.Symbol]backPointer, derivation *[]grammar.Production) *parsetree.Node {
+	backPointer := backPointers[end-start-1][start][nonterminal]
+	*derivation = append(*derivation, backPointer.production)
+
+	node := &parsetree.Node{Symbol: string(nonterminal)}
+
+	if backPointer.split == 0 {
+		token := tokens[start]
+		node.Children = []*parsetree.Node{{
+			Symbol:   string(token.Kind),
+			Terminal: true,
+			Lexeme:   token.Lexeme,
+			Span:     parsetree.Span{Start: token.Pos.Offset, End: token.Pos.Offset + len(token.Lexeme)},
+		}}
+
+		return node
+	}
+
+	split := start + backPointer.split
+	node.Children = []*parsetree.Node{
+		build(backPointer.production.Body[0], start, split, tokens, backPointers, derivation),
+		build(backPointer.production.Body[1], split, end, tokens, backPointers, derivation),
+	}
+
+	return node
+}
+
+/*
+	Renders the table as a text table with a column for every token and a row for every length, the single tokens first.
+	Each cell holds its nonterminals sorted and separated by commas, and cells past the last token are left out.
+*/
+func (table Table) String() string {
+	var builder strings.Builder
+	writer := tabwriter.NewWriter(&builder, 0, 0, 1, ' ', tabwriter.Debug)
+
+	writer.Write([]byte(" "))
+	for _, token := range table.Tokens {
+		writer.Write([]byte("\t " + token.Lexeme + " "))
+	}
+	writer.Write([]byte("\t\n"))
+
+	for length, row := range table.Cells {
+		writer.Write([]byte(" " + strconv.Itoa(length+1) + " "))
+
+		for _, cell := range row {
+			var symbols []string
+			for _, symbol := range cell.Sorted() {
+				symbols = append(symbols, string(symbol))
+			}
+
+			writer.Write([]byte("\t " + strings.Join(symbols, ",") + " "))
+		}
+		writer.Write([]byte("\t\n"))
+	}
+
+	writer.Flush()
+
+	return builder.String()
+}
@@ -0,0 +1,147 @@
+package cfg
+
+import (
+	"flfa/grammar"
+	"flfa/lexer"
+	"flfa/ll1"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCYK(t *testing.T) {
+	g, _, err := ToCNF(newUnnormalizedGrammar(t))
+	assert.Equal(t, nil, err)
+
+	result, err := CYK(g, lexer.Runes("aab"))
+
+	assert.Equal(t, nil, err)
+	assert.True(t, result.IsAccepted)
+	assert.Equal(t, `    | a               | a               | b    |
+ 1  | A,S,S',S_1,T_a  | A,S,S',S_1,T_a  | A,B  |
+ 2  | A,S,S',S_1      | A,S,S',S_1      |
+ 3  | A,S,S',S_1      |
+`, result.Table.String())
+	assert.Equal(t, `S' [0,3)
+  A [0,1)
+    a "a" [0,1)
+  S_1 [1,3)
+    S [1,2)
+      a "a" [1,2)
+    A [2,3)
+      b "b" [2,3)
+`, result.Tree.String())
+
+	var derivation []string
+	for _, production := range result.Derivation {
+		derivation = append(derivation, production.String())
+	}
+
+	assert.Equal(t, []string{"S' -> A S_1", "A -> a", "S_1 -> S A", "S -> a", "A -> b"}, derivation)
+
+	result, err = CYK(g, lexer.Runes("bb"))
+	assert.Equal(t, nil, err)
+	assert.False(t, result.IsAccepted)
+	assert.Nil(t, result.Tree)
+	assert.Nil(t, result.Derivation)
+
+	result, err = CYK(g, nil)
+	assert.Equal(t, nil, err)
+	assert.False(t, result.IsAccepted)
+
+	_, err = CYK(newUnnormalizedGrammar(t), lexer.Runes("ab"))
+	assert.Equal(t, fmt.Errorf("the production 'S -> A S A' is not in Chomsky normal form"), err)
+}
+
+func TestCYKEmpty(t *testing.T) {
+	g, err := grammar.NewGrammar(
+		[]grammar.Symbol{"S"},
+		[]grammar.Symbol{"(", ")"},
+		"S",
+		[]grammar.Production{
+			{Head: "S", Body: []grammar.Symbol{"(", "S", ")", "S"}},
+			{Head: "S", Body: []grammar.Symbol{}},
+		},
+	)
+	assert.Equal(t, nil, err)
+
+	g, _, err = ToCNF(g)
+	assert.Equal(t, nil, err)
+
+	result, err := CYK(g, lexer.Runes(""))
+
+	assert.Equal(t, nil, err)
+	assert.True(t, result.IsAccepted)
+	assert.Equal(t, "S' [0,0)\n  ε\n", result.Tree.String())
+	assert.Equal(t, []grammar.Production{{Head: "S'", Body: []grammar.Symbol{}}}, result.Derivation)
+}
+
+func TestCYKAgainstLL1(t *testing.T) {
+	// Every string of up to five terminals is accepted by CYK exactly when the LL(1) parser accepts it
+	var grammars = []struct {
+		nonterminals []grammar.Symbol
+		terminals    []grammar.Symbol
+		productions  []grammar.Production
+	}{
+		{
+			[]grammar.Symbol{"S"},
+			[]grammar.Symbol{"(", ")", "[", "]"},
+			[]grammar.Production{
+				{Head: "S", Body: []grammar.Symbol{"(", "S", ")", "S"}},
+				{Head: "S", Body: []grammar.Symbol{"[", "S", "]", "S"}},
+				{Head: "S", Body: []grammar.Symbol{}},
+			},
+		},
+		{
+			[]grammar.Symbol{"E", "E'", "T", "T'", "F"},
+			[]grammar.Symbol{"+", "*", "(", ")", "a"},
+			[]grammar.Production{
+				{Head: "E", Body: []grammar.Symbol{"T", "E'"}},
+				{Head: "E'", Body: []grammar.Symbol{"+", "T", "E'"}},
+				{Head: "E'", Body: []grammar.Symbol{}},
+				{Head: "T", Body: []grammar.Symbol{"F", "T'"}},
+				{Head: "T'", Body: []grammar.Symbol{"*", "F", "T'"}},
+				{Head: "T'", Body: []grammar.Symbol{}},
+				{Head: "F", Body: []grammar.Symbol{"(", "E", ")"}},
+				{Head: "F", Body: []grammar.Symbol{"a"}},
+			},
+		},
+	}
+
+	for _, tg := range grammars {
+		g, err := grammar.NewGrammar(tg.nonterminals, tg.terminals, tg.nonterminals[0], tg.productions)
+		assert.Equal(t, nil, err)
+
+		ll1Parser, err := ll1.NewLL1FromGrammar(g, nil)
+		assert.Equal(t, nil, err)
+
+		cnf, _, err := ToCNF(g)
+		assert.Equal(t, nil, err)
+
+		accepted := 0
+		strs := []string{""}
+
+		for length := 0; length <= 5; length++ {
+			var next []string
+
+			for _, str := range strs {
+				result, err := CYK(cnf, lexer.Runes(str))
+				assert.Equal(t, nil, err)
+				assert.Equal(t, ll1Parser.Solve(str) == nil, result.IsAccepted, "string '%v'", str)
+
+				if result.IsAccepted {
+					accepted++
+				}
+
+				for _, terminal := range tg.terminals {
+					next = append(next, str+string(terminal))
+				}
+			}
+
+			strs = next
+		}
+
+		assert.NotEqual(t, 0, accepted)
+	}
+}
@@ -0,0 +1,423 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"flfa/automaton"
+	"flfa/dfa"
+	"flfa/regex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	exitSuccess = 0
+	exitFailure = 1
+	exitError   = 2
+)
+
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(flags *flag.FlagSet, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int
+}
+
+var commands = []command{
+	{"run", "run [-i file] <machine> [input...]", "solves a machine for every input and reports if it is accepted", runRun},
+	{"validate", "validate <machine>...", "checks that definition files hold valid machines", runValidate},
+	{"minimize", "minimize [-o file] [-format json|yaml] <machine>", "writes the minimal DFA of a machine", runMinimize},
+	{"convert", "convert [-to dfa|regex] [-o file] [-format json|yaml] <machine>", "writes a machine as a DFA or as a regular expression", runConvert},
+	{"export", "export [-o file] <machine>", "writes a machine as a Graphviz DOT digraph", runExport},
+	{"equiv", "equiv <machine> <machine>", "checks if two machines accept the same language", runEquiv},
+}
+
+/*
+	Runs the command named by the first argument and returns the exit status.
+*/
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(stderr)
+
+		if len(args) == 0 {
+			return exitError
+		}
+
+		return exitSuccess
+	}
+
+	for _, command := range commands {
+		if command.name != args[0] {
+			continue
+		}
+
+		flags := flag.NewFlagSet(command.name, flag.ContinueOnError)
+		flags.SetOutput(stderr)
+		flags.Usage = func() {
+			fmt.Fprintf(stderr, "usage: flfa %v\n", command.usage)
+			flags.PrintDefaults()
+		}
+
+		return command.run(flags, args[1:], stdin, stdout, stderr)
+	}
+
+	fmt.Fprintf(stderr, "flfa: unknown command '%v'\n", args[0])
+	printUsage(stderr)
+
+	return exitError
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "usage: flfa <command> [flags] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+
+	for _, command := range commands {
+		fmt.Fprintf(w, "  %-9v %v\n", command.name, command.summary)
+	}
+}
+
+/*
+	Solves a machine for inputs given as arguments, or line by line from a file or stdin.
+	Lines are solved as they are read, so that results are printed while the input is still being written.
+*/
+func runRun(flags *flag.FlagSet, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	inputFile := flags.String("i", "", "read inputs line by line from `file` instead of stdin")
+
+	machine, args, status, ok := parseMachineArgs(flags, args, stderr)
+	if !ok {
+		return status
+	}
+
+	if len(args) != 0 && *inputFile != "" {
+		fmt.Fprintf(stderr, "flfa: the inputs cannot be given both as arguments and with -i\n")
+		return exitError
+	}
+
+	if len(args) != 0 {
+		for _, input := range args {
+			status = worseStatus(status, solveInput(machine, input, stdout))
+		}
+
+		return status
+	}
+
+	lines := stdin
+	if *inputFile != "" {
+		file, err := os.Open(*inputFile)
+		if err != nil {
+			fmt.Fprintf(stderr, "flfa: %v\n", err)
+			return exitError
+		}
+		defer file.Close()
+
+		lines = file
+	}
+
+	scanner := bufio.NewScanner(lines)
+	for scanner.Scan() {
+		status = worseStatus(status, solveInput(machine, strings.TrimSuffix(scanner.Text(), "\r"), stdout))
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		fmt.Fprintf(stderr, "flfa: %v\n", err)
+		return exitError
+	}
+
+	return status
+}
+
+/*
+	Solves a machine for one input, prints the result and returns the exit status of the input alone.
+*/
+func solveInput(machine automaton.Automaton, input string, stdout io.Writer) int {
+	isAccepting, err := machine.Accepts(input)
+	if err != nil {
+		fmt.Fprintf(stdout, "%q\terror: %v\n", input, err)
+		return exitError
+	}
+
+	if !isAccepting {
+		fmt.Fprintf(stdout, "%q\trejected\n", input)
+		return exitFailure
+	}
+
+	fmt.Fprintf(stdout, "%q\taccepted\n", input)
+	return exitSuccess
+}
+
+/*
+	Gets the worse of two exit statuses, where an error is worse than a failure.
+*/
+func worseStatus(a int, b int) int {
+	if b > a {
+		return b
+	}
+
+	return a
+}
+
+/*
+	Checks that every definition file holds a valid machine.
+*/
+func runValidate(flags *flag.FlagSet, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	status, ok := parseFlags(flags, args)
+	if !ok {
+		return status
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitError
+	}
+
+	status = exitSuccess
+	for _, path := range flags.Args() {
+		machine, err := automaton.Load(path)
+		if err != nil {
+			fmt.Fprintf(stdout, "%v: invalid: %v\n", path, err)
+			status = exitFailure
+			continue
+		}
+
+		fmt.Fprintf(stdout, "%v: valid %v\n", path, machine.Kind)
+	}
+
+	return status
+}
+
+/*
+	Writes the minimal DFA of a machine, converting NFAs first.
+*/
+func runMinimize(flags *flag.FlagSet, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	output := flags.String("o", "", "write to `file` instead of stdout")
+	format := flags.String("format", "", "write in `format` json or yaml, by default the format of -o or yaml")
+
+	machine, args, status, ok := parseMachineArgs(flags, args, stderr)
+	if !ok {
+		return status
+	}
+
+	if len(args) != 0 {
+		flags.Usage()
+		return exitError
+	}
+
+	d, err := machine.ToDFA()
+	if err != nil {
+		fmt.Fprintf(stderr, "flfa: %v\n", err)
+		return exitError
+	}
+
+	minimized, _, err := d.Minimize()
+	if err != nil {
+		fmt.Fprintf(stderr, "flfa: %v\n", err)
+		return exitError
+	}
+
+	return writeMachine(automaton.Automaton{Kind: automaton.DFA, DFA: minimized}, *output, *format, stdout, stderr)
+}
+
+/*
+	Writes a machine as a DFA, converting NFAs with the subset construction, or as a regular expression.
+*/
+func runConvert(flags *flag.FlagSet, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	to := flags.String("to", "dfa", "convert to `kind` dfa or regex")
+	output := flags.String("o", "", "write to `file` instead of stdout")
+	format := flags.String("format", "", "write in `format` json or yaml, by default the format of -o or yaml")
+
+	machine, args, status, ok := parseMachineArgs(flags, args, stderr)
+	if !ok {
+		return status
+	}
+
+	if len(args) != 0 {
+		flags.Usage()
+		return exitError
+	}
+
+	d, err := machine.ToDFA()
+	if err != nil {
+		fmt.Fprintf(stderr, "flfa: %v\n", err)
+		return exitError
+	}
+
+	switch *to {
+	case "dfa":
+		return writeMachine(automaton.Automaton{Kind: automaton.DFA, DFA: d}, *output, *format, stdout, stderr)
+	case "regex":
+		node, err := regex.FromDFA(d)
+		if err != nil {
+			fmt.Fprintf(stderr, "flfa: %v\n", err)
+			return exitError
+		}
+
+		return writeOutput([]byte(node.String()+"\n"), *output, stdout, stderr)
+	}
+
+	fmt.Fprintf(stderr, "flfa: the kind '%v' must be either 'dfa' or 'regex'\n", *to)
+	return exitError
+}
+
+/*
+	Writes a machine as a Graphviz DOT digraph.
+*/
+func runExport(flags *flag.FlagSet, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	output := flags.String("o", "", "write to `file` instead of stdout")
+
+	machine, args, status, ok := parseMachineArgs(flags, args, stderr)
+	if !ok {
+		return status
+	}
+
+	if len(args) != 0 {
+		flags.Usage()
+		return exitError
+	}
+
+	return writeOutput([]byte(machine.ToDOT()), *output, stdout, stderr)
+}
+
+/*
+	Checks if two machines accept the same language and prints a shortest counterexample if they do not.
+*/
+func runEquiv(flags *flag.FlagSet, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	status, ok := parseFlags(flags, args)
+	if !ok {
+		return status
+	}
+
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return exitError
+	}
+
+	paths := flags.Args()
+	dfas := make([]dfa.DFA, 2)
+	for i, path := range paths {
+		machine, err := automaton.Load(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "flfa: %v\n", err)
+			return exitError
+		}
+
+		d, err := machine.ToDFA()
+		if err != nil {
+			fmt.Fprintf(stderr, "flfa: %v: %v\n", path, err)
+			return exitError
+		}
+
+		dfas[i] = d
+	}
+
+	isEquivalent, counterexample, err := dfa.Equivalent(dfas[0], dfas[1])
+	if err != nil {
+		fmt.Fprintf(stderr, "flfa: %v\n", err)
+		return exitError
+	}
+
+	if isEquivalent {
+		fmt.Fprintln(stdout, "equivalent")
+		return exitSuccess
+	}
+
+	accepting, rejecting := paths[0], paths[1]
+	if counterexample.IsAcceptedBySecond {
+		accepting, rejecting = paths[1], paths[0]
+	}
+
+	fmt.Fprintf(stdout, "not equivalent: %q is accepted by %v but not by %v\n", counterexample.Str, accepting, rejecting)
+	return exitFailure
+}
+
+/*
+	Parses the flags and reports if the command should go on.
+	Otherwise the exit status is returned, which is a success if only the help was asked for.
+*/
+func parseFlags(flags *flag.FlagSet, args []string) (int, bool) {
+	err := flags.Parse(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return exitSuccess, false
+	}
+
+	if err != nil {
+		return exitError, false
+	}
+
+	return exitSuccess, true
+}
+
+/*
+	Parses the flags followed by a machine definition file and loads the machine.
+	The arguments after the machine are returned, and flags must come before the machine.
+	The exit status is only meaningful if the command should not go on.
+*/
+func parseMachineArgs(flags *flag.FlagSet, args []string, stderr io.Writer) (automaton.Automaton, []string, int, bool) {
+	status, ok := parseFlags(flags, args)
+	if !ok {
+		return automaton.Automaton{}, nil, status, false
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return automaton.Automaton{}, nil, exitError, false
+	}
+
+	machine, err := automaton.Load(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "flfa: %v\n", err)
+		return automaton.Automaton{}, nil, exitError, false
+	}
+
+	return machine, flags.Args()[1:], exitSuccess, true
+}
+
+/*
+	Writes a machine definition to a file or stdout.
+	The format is taken from the format flag, then from the file's extension, and is YAML otherwise.
+*/
+func writeMachine(machine automaton.Automaton, output string, format string, stdout io.Writer, stderr io.Writer) int {
+	if format == "" {
+		format = string(automaton.YAML)
+
+		if output != "" {
+			outputFormat, err := automaton.FormatOf(output)
+			if err != nil {
+				fmt.Fprintf(stderr, "flfa: %v\n", err)
+				return exitError
+			}
+
+			format = string(outputFormat)
+		}
+	}
+
+	data, err := automaton.Marshal(machine, automaton.Format(format))
+	if err != nil {
+		fmt.Fprintf(stderr, "flfa: %v\n", err)
+		return exitError
+	}
+
+	return writeOutput(data, output, stdout, stderr)
+}
+
+/*
+	Writes data to a file, or to stdout if no file is given.
+*/
+func writeOutput(data []byte, output string, stdout io.Writer, stderr io.Writer) int {
+	if output == "" {
+		stdout.Write(data)
+		return exitSuccess
+	}
+
+	err := os.WriteFile(output, data, 0644)
+	if err != nil {
+		fmt.Fprintf(stderr, "flfa: %v\n", err)
+		return exitError
+	}
+
+	return exitSuccess
+}
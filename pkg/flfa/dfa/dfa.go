@@ -12,6 +12,11 @@ type State string
 type Symbol rune
 type Delta map[State]map[Symbol]State
 
+/*
+	The exported name of a DFA, so that other packages can build and accept DFAs.
+*/
+type DFA = dfa
+
 type dfa struct {
 	states          []State
 	alphabet        []Symbol
@@ -76,6 +81,48 @@ func (dfa *dfa) Solve(str string) (State, bool, error) {
 	return state, ok, nil
 }
 
+/*
+	Gets a copy of the DFA's states.
+*/
+func (dfa *dfa) States() []State {
+	return append([]State(nil), dfa.states...)
+}
+
+/*
+	Gets a copy of the DFA's alphabet.
+*/
+func (dfa *dfa) Alphabet() []Symbol {
+	return append([]Symbol(nil), dfa.alphabet...)
+}
+
+/*
+	Gets the state the DFA moves to from a state by reading a symbol.
+*/
+func (dfa *dfa) Transition(state State, symbol Symbol) State {
+	return dfa.delta[state][symbol]
+}
+
+/*
+	Gets the DFA's starting state.
+*/
+func (dfa *dfa) StartingState() State {
+	return dfa.startingState
+}
+
+/*
+	Gets a copy of the DFA's accepting states.
+*/
+func (dfa *dfa) AcceptingStates() []State {
+	return append([]State(nil), dfa.acceptingStates...)
+}
+
+/*
+	Checks if a state is one of the DFA's accepting states.
+*/
+func (dfa *dfa) IsAccepting(state State) bool {
+	return dfa.isStateAccepting(state)
+}
+
 /*
 	Validates the entire DFA.
 */
@@ -0,0 +1,62 @@
+package dfa
+
+import (
+	"flfa/internal/dot"
+	"fmt"
+	"strings"
+)
+
+/*
+	Renders the DFA as a Graphviz DOT digraph.
+	The starting state has an arrow from a point, accepting states are double circles,
+	and parallel edges are merged into one edge with a comma separated label in the order of the alphabet.
+	States are numbered in the order of the states array and labelled with their names,
+	so that no state name can clash with the point or with another state.
+	The output only depends on the order of the states and the alphabet, so it can be compared between runs.
+*/
+func (dfa *dfa) ToDOT() string {
+	var builder strings.Builder
+
+	builder.WriteString("digraph {\n")
+	builder.WriteString("\trankdir=LR;\n")
+	builder.WriteString("\tnode [shape=circle];\n")
+	builder.WriteString("\tstart [shape=point];\n")
+
+	ids := make(map[State]int, len(dfa.states))
+	for i, state := range dfa.states {
+		ids[state] = i
+
+		if dfa.isStateAccepting(state) {
+			fmt.Fprintf(&builder, "\tn%v [label=%v, shape=doublecircle];\n", i, dot.Quote(string(state)))
+		} else {
+			fmt.Fprintf(&builder, "\tn%v [label=%v];\n", i, dot.Quote(string(state)))
+		}
+	}
+
+	fmt.Fprintf(&builder, "\tstart -> n%v;\n", ids[dfa.startingState])
+
+	for _, state := range dfa.states {
+		var newStates []State
+		labels := make(map[State][]string)
+
+		for _, symbol := range dfa.alphabet {
+			newState, ok := dfa.delta[state][symbol]
+			if !ok {
+				continue
+			}
+
+			if _, ok := labels[newState]; !ok {
+				newStates = append(newStates, newState)
+			}
+			labels[newState] = append(labels[newState], string(symbol))
+		}
+
+		for _, newState := range newStates {
+			fmt.Fprintf(&builder, "\tn%v -> n%v [label=%v];\n", ids[state], ids[newState], dot.Quote(strings.Join(labels[newState], ",")))
+		}
+	}
+
+	builder.WriteString("}\n")
+
+	return builder.String()
+}
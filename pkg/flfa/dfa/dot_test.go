@@ -0,0 +1,40 @@
+package dfa
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDFAToDOT(t *testing.T) {
+	golden, err := ioutil.ReadFile("testdata/evenAs.dot")
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, string(golden), evenAs.ToDOT())
+
+	golden, err = ioutil.ReadFile("testdata/endsInB.dot")
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, string(golden), endsInB.ToDOT())
+}
+
+func TestDFAToDOTReservedNames(t *testing.T) {
+	// States named like the point or like the numbered nodes are only labels
+	d, err := NewDFA([]State{"start", "__start", "n0"}, []Symbol{'a'}, Delta{"start": {'a': "__start"}, "__start": {'a': "n0"}, "n0": {'a': "n0"}}, "__start", []State{"start"})
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, `digraph {
+	rankdir=LR;
+	node [shape=circle];
+	start [shape=point];
+	n0 [label="start", shape=doublecircle];
+	n1 [label="__start"];
+	n2 [label="n0"];
+	start -> n1;
+	n0 -> n1 [label="a"];
+	n1 -> n2 [label="a"];
+	n2 -> n2 [label="a"];
+}
+`, d.ToDOT())
+}
@@ -0,0 +1,75 @@
+package dfa
+
+/*
+	A string that is accepted by exactly one of two DFAs.
+*/
+type Counterexample struct {
+	Str                string
+	IsAcceptedByFirst  bool
+	IsAcceptedBySecond bool
+}
+
+/*
+	Checks if two DFAs over the same alphabet accept the same language.
+	The DFAs may use different state names.
+	If they are not equivalent, then a shortest string that distinguishes them is returned.
+	If either DFA fails validation or the alphabets do not match, then false and an error are returned.
+*/
+func Equivalent(a dfa, b dfa) (bool, *Counterexample, error) {
+	err := validatePair(&a, &b)
+	if err != nil {
+		return false, nil, err
+	}
+
+	type pair struct {
+		a State
+		b State
+	}
+
+	// Every visited pair remembers the pair and symbol it was first reached from
+	type step struct {
+		previous pair
+		symbol   Symbol
+	}
+
+	start := pair{a.startingState, b.startingState}
+	steps := map[pair]step{start: {}}
+	queue := []pair{start}
+
+	// A breadth-first search reaches every pair by a shortest string first
+	for len(queue) != 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if a.isStateAccepting(current.a) != b.isStateAccepting(current.b) {
+			var symbols []rune
+			for state := current; state != start; state = steps[state].previous {
+				symbols = append([]rune{rune(steps[state].symbol)}, symbols...)
+			}
+			str := string(symbols)
+
+			_, isAcceptedByFirst, err := a.Solve(str)
+			if err != nil {
+				return false, nil, err
+			}
+
+			_, isAcceptedBySecond, err := b.Solve(str)
+			if err != nil {
+				return false, nil, err
+			}
+
+			return false, &Counterexample{str, isAcceptedByFirst, isAcceptedBySecond}, nil
+		}
+
+		for _, symbol := range a.alphabet {
+			next := pair{a.delta[current.a][symbol], b.delta[current.b][symbol]}
+
+			if _, ok := steps[next]; !ok {
+				steps[next] = step{current, symbol}
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return true, nil, nil
+}
@@ -0,0 +1,102 @@
+package dfa
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEquivalent(t *testing.T) {
+	// Accepts the same language as evenAs with different state names and a redundant state
+	evenAsRenamed := dfa{
+		[]State{"p0", "p1", "p2"},
+		[]Symbol{'b', 'a'},
+		Delta{
+			"p0": {
+				'a': "p1",
+				'b': "p2",
+			},
+			"p1": {
+				'a': "p2",
+				'b': "p1",
+			},
+			"p2": {
+				'a': "p1",
+				'b': "p0",
+			},
+		},
+		State("p0"),
+		[]State{"p0", "p2"},
+	}
+
+	var tests = []struct {
+		a              dfa
+		b              dfa
+		isEquivalent   bool
+		counterexample *Counterexample
+	}{
+		{evenAs, evenAs, true, nil},
+		{evenAs, evenAsRenamed, true, nil},
+		{evenAs, endsInB, false, &Counterexample{"", true, false}},
+		{endsInB, evenAs, false, &Counterexample{"", false, true}},
+		{
+			// Differs from evenAs only on strings with exactly two a's in a row at the start
+			evenAs,
+			dfa{
+				[]State{"s", "a", "aa", "e", "o"},
+				[]Symbol{'a', 'b'},
+				Delta{
+					"s": {
+						'a': "a",
+						'b': "e",
+					},
+					"a": {
+						'a': "aa",
+						'b': "o",
+					},
+					"aa": {
+						'a': "o",
+						'b': "e",
+					},
+					"e": {
+						'a': "o",
+						'b': "e",
+					},
+					"o": {
+						'a': "e",
+						'b': "o",
+					},
+				},
+				State("s"),
+				[]State{"s", "e"},
+			},
+			false,
+			&Counterexample{"aa", true, false},
+		},
+	}
+
+	for _, tt := range tests {
+		isEquivalent, counterexample, err := Equivalent(tt.a, tt.b)
+
+		assert.Equal(t, nil, err)
+		assert.Equal(t, tt.isEquivalent, isEquivalent)
+		assert.Equal(t, tt.counterexample, counterexample)
+	}
+}
+
+func TestEquivalentInvalid(t *testing.T) {
+	invalid := dfa{
+		[]State{"q0"},
+		[]Symbol{'a', 'b'},
+		Delta{},
+		State("q0"),
+		[]State{},
+	}
+
+	isEquivalent, counterexample, err := Equivalent(evenAs, invalid)
+
+	assert.Equal(t, fmt.Errorf("the second DFA is invalid: delta is not defined for the state 'q0' and the symbol 'a'"), err)
+	assert.False(t, isEquivalent)
+	assert.Nil(t, counterexample)
+}
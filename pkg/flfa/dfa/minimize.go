@@ -0,0 +1,217 @@
+package dfa
+
+import (
+	"sort"
+)
+
+type splitter struct {
+	block  int
+	symbol int
+}
+
+/*
+	Minimizes the DFA using Hopcroft's partition refinement.
+	Unreachable states are removed and equivalent states are merged into the first of them in the states array.
+	The mapping from every reachable state to the state it was merged into is returned alongside the new DFA.
+	If the DFA fails validation, then an empty DFA is returned.
+*/
+func (dfa *dfa) Minimize() (dfa, map[State]State, error) {
+	err := dfa.validate()
+	if err != nil {
+		return initializeDFA(), nil, err
+	}
+
+	states := dfa.reachableStates()
+
+	stateIndices := make(map[State]int, len(states))
+	for i, state := range states {
+		stateIndices[state] = i
+	}
+
+	blockOf := dfa.refinePartition(states, stateIndices)
+
+	// The representative of a block is its first state, so merged states keep a familiar name
+	representatives := make(map[int]State)
+	var minimizedStates []State
+	for i, state := range states {
+		if _, ok := representatives[blockOf[i]]; !ok {
+			representatives[blockOf[i]] = state
+			minimizedStates = append(minimizedStates, state)
+		}
+	}
+
+	mapping := make(map[State]State, len(states))
+	for i, state := range states {
+		mapping[state] = representatives[blockOf[i]]
+	}
+
+	delta := make(Delta, len(minimizedStates))
+	var acceptingStates []State
+	for _, state := range minimizedStates {
+		delta[state] = make(map[Symbol]State, len(dfa.alphabet))
+
+		for _, symbol := range dfa.alphabet {
+			delta[state][symbol] = mapping[dfa.delta[state][symbol]]
+		}
+
+		if dfa.isStateAccepting(state) {
+			acceptingStates = append(acceptingStates, state)
+		}
+	}
+
+	alphabet := append([]Symbol(nil), dfa.alphabet...)
+
+	minimized, err := NewDFA(minimizedStates, alphabet, delta, mapping[dfa.startingState], acceptingStates)
+	if err != nil {
+		return initializeDFA(), nil, err
+	}
+
+	return minimized, mapping, nil
+}
+
+/*
+	Finds the states reachable from the starting state.
+	The states are returned in the same order as the DFA's states array.
+*/
+func (dfa *dfa) reachableStates() []State {
+	visited := map[State]bool{dfa.startingState: true}
+	queue := []State{dfa.startingState}
+
+	for len(queue) != 0 {
+		state := queue[0]
+		queue = queue[1:]
+
+		for _, symbol := range dfa.alphabet {
+			newState := dfa.delta[state][symbol]
+			if !visited[newState] {
+				visited[newState] = true
+				queue = append(queue, newState)
+			}
+		}
+	}
+
+	var reachable []State
+	for _, state := range dfa.states {
+		if visited[state] {
+			reachable = append(reachable, state)
+		}
+	}
+
+	return reachable
+}
+
+/*
+	Partitions the given states into blocks of equivalent states.
+	The block of every state is returned by the state's index.
+*/
+func (dfa *dfa) refinePartition(states []State, stateIndices map[State]int) []int {
+	// inverse[symbol][state] holds every state that moves to state on symbol
+	inverse := make([][][]int, len(dfa.alphabet))
+	for j, symbol := range dfa.alphabet {
+		inverse[j] = make([][]int, len(states))
+
+		for i, state := range states {
+			newState := stateIndices[dfa.delta[state][symbol]]
+			inverse[j][newState] = append(inverse[j][newState], i)
+		}
+	}
+
+	var accepting, rejecting []int
+	for i, state := range states {
+		if dfa.isStateAccepting(state) {
+			accepting = append(accepting, i)
+		} else {
+			rejecting = append(rejecting, i)
+		}
+	}
+
+	var blocks [][]int
+	for _, block := range [][]int{accepting, rejecting} {
+		if len(block) != 0 {
+			blocks = append(blocks, block)
+		}
+	}
+
+	blockOf := make([]int, len(states))
+	for b, block := range blocks {
+		for _, i := range block {
+			blockOf[i] = b
+		}
+	}
+
+	if len(blocks) < 2 {
+		return blockOf
+	}
+
+	var worklist []splitter
+	pending := make(map[splitter]bool)
+	push := func(s splitter) {
+		worklist = append(worklist, s)
+		pending[s] = true
+	}
+
+	smallest := 0
+	if len(blocks[1]) < len(blocks[0]) {
+		smallest = 1
+	}
+	for j := range dfa.alphabet {
+		push(splitter{smallest, j})
+	}
+
+	for len(worklist) != 0 {
+		current := worklist[0]
+		worklist = worklist[1:]
+		delete(pending, current)
+
+		// Groups the predecessors of the splitter by the block they are in
+		touched := make(map[int][]int)
+		for _, i := range blocks[current.block] {
+			for _, predecessor := range inverse[current.symbol][i] {
+				touched[blockOf[predecessor]] = append(touched[blockOf[predecessor]], predecessor)
+			}
+		}
+
+		// Splits blocks in a fixed order so the result does not depend on map iteration
+		touchedBlocks := make([]int, 0, len(touched))
+		for b := range touched {
+			touchedBlocks = append(touchedBlocks, b)
+		}
+		sort.Ints(touchedBlocks)
+
+		for _, b := range touchedBlocks {
+			inside := touched[b]
+			if len(inside) == len(blocks[b]) {
+				continue
+			}
+
+			isInside := make(map[int]bool, len(inside))
+			for _, i := range inside {
+				isInside[i] = true
+			}
+
+			var outside []int
+			for _, i := range blocks[b] {
+				if !isInside[i] {
+					outside = append(outside, i)
+				}
+			}
+
+			newBlock := len(blocks)
+			blocks[b] = inside
+			blocks = append(blocks, outside)
+			for _, i := range outside {
+				blockOf[i] = newBlock
+			}
+
+			for j := range dfa.alphabet {
+				if pending[splitter{b, j}] || len(outside) <= len(inside) {
+					push(splitter{newBlock, j})
+				} else {
+					push(splitter{b, j})
+				}
+			}
+		}
+	}
+
+	return blockOf
+}
@@ -0,0 +1,178 @@
+package dfa
+
+import (
+	"flfa/internal/testutil"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDFAMinimize(t *testing.T) {
+	var tests = []struct {
+		dfa     dfa
+		want    dfa
+		mapping map[State]State
+	}{
+		{
+			// Accepts strings ending in 'a', with a duplicated accepting state and an unreachable state
+			dfa{
+				[]State{"q0", "q1", "q2", "q3"},
+				[]Symbol{'a', 'b'},
+				Delta{
+					"q0": {
+						'a': "q1",
+						'b': "q0",
+					},
+					"q1": {
+						'a': "q2",
+						'b': "q0",
+					},
+					"q2": {
+						'a': "q1",
+						'b': "q0",
+					},
+					"q3": {
+						'a': "q0",
+						'b': "q3",
+					},
+				},
+				State("q0"),
+				[]State{"q1", "q2"},
+			},
+			dfa{
+				[]State{"q0", "q1"},
+				[]Symbol{'a', 'b'},
+				Delta{
+					"q0": {
+						'a': "q1",
+						'b': "q0",
+					},
+					"q1": {
+						'a': "q1",
+						'b': "q0",
+					},
+				},
+				State("q0"),
+				[]State{"q1"},
+			},
+			map[State]State{"q0": "q0", "q1": "q1", "q2": "q1"},
+		},
+		{
+			// Accepts nothing, so every state collapses into the starting state
+			dfa{
+				[]State{"q0", "q1"},
+				[]Symbol{'a'},
+				Delta{
+					"q0": {
+						'a': "q1",
+					},
+					"q1": {
+						'a': "q0",
+					},
+				},
+				State("q0"),
+				[]State{},
+			},
+			dfa{
+				[]State{"q0"},
+				[]Symbol{'a'},
+				Delta{
+					"q0": {
+						'a': "q0",
+					},
+				},
+				State("q0"),
+				[]State(nil),
+			},
+			map[State]State{"q0": "q0", "q1": "q0"},
+		},
+		{
+			// Counts the number of '1's modulo 3 twice over, so six states merge into three
+			dfa{
+				[]State{"q0", "q1", "q2", "q3", "q4", "q5"},
+				[]Symbol{'0', '1'},
+				Delta{
+					"q0": {
+						'0': "q0",
+						'1': "q1",
+					},
+					"q1": {
+						'0': "q4",
+						'1': "q2",
+					},
+					"q2": {
+						'0': "q2",
+						'1': "q3",
+					},
+					"q3": {
+						'0': "q3",
+						'1': "q4",
+					},
+					"q4": {
+						'0': "q1",
+						'1': "q5",
+					},
+					"q5": {
+						'0': "q5",
+						'1': "q0",
+					},
+				},
+				State("q0"),
+				[]State{"q0", "q3"},
+			},
+			dfa{
+				[]State{"q0", "q1", "q2"},
+				[]Symbol{'0', '1'},
+				Delta{
+					"q0": {
+						'0': "q0",
+						'1': "q1",
+					},
+					"q1": {
+						'0': "q1",
+						'1': "q2",
+					},
+					"q2": {
+						'0': "q2",
+						'1': "q0",
+					},
+				},
+				State("q0"),
+				[]State{"q0"},
+			},
+			map[State]State{"q0": "q0", "q1": "q1", "q2": "q2", "q3": "q0", "q4": "q1", "q5": "q2"},
+		},
+	}
+
+	for _, tt := range tests {
+		minimized, mapping, err := tt.dfa.Minimize()
+
+		assert.Equal(t, nil, err)
+		assert.Equal(t, tt.want, minimized)
+		assert.Equal(t, tt.mapping, mapping)
+
+		for _, str := range testutil.Strings(tt.dfa.alphabet, 6) {
+			_, want, _ := tt.dfa.Solve(str)
+			_, got, _ := minimized.Solve(str)
+
+			assert.Equal(t, want, got, "string '%v'", str)
+		}
+	}
+}
+
+func TestDFAMinimizeInvalid(t *testing.T) {
+	invalid := dfa{
+		[]State{"q0"},
+		[]Symbol{'a'},
+		Delta{},
+		State("q0"),
+		[]State{},
+	}
+
+	minimized, mapping, err := invalid.Minimize()
+
+	assert.Equal(t, fmt.Errorf("delta is not defined for the state 'q0' and the symbol 'a'"), err)
+	assert.Equal(t, initializeDFA(), minimized)
+	assert.Nil(t, mapping)
+}
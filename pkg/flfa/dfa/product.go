@@ -0,0 +1,152 @@
+package dfa
+
+import (
+	"flfa/internal/statename"
+	"fmt"
+)
+
+/*
+	Creates a DFA that accepts the strings accepted by both DFAs.
+*/
+func Intersect(a dfa, b dfa) (dfa, error) {
+	return product(a, b, func(isAcceptingA bool, isAcceptingB bool) bool {
+		return isAcceptingA && isAcceptingB
+	})
+}
+
+/*
+	Creates a DFA that accepts the strings accepted by either DFA.
+*/
+func Union(a dfa, b dfa) (dfa, error) {
+	return product(a, b, func(isAcceptingA bool, isAcceptingB bool) bool {
+		return isAcceptingA || isAcceptingB
+	})
+}
+
+/*
+	Creates a DFA that accepts the strings accepted by the first DFA but not by the second DFA.
+*/
+func Difference(a dfa, b dfa) (dfa, error) {
+	return product(a, b, func(isAcceptingA bool, isAcceptingB bool) bool {
+		return isAcceptingA && !isAcceptingB
+	})
+}
+
+/*
+	Creates a DFA that accepts the strings accepted by exactly one of the DFAs.
+*/
+func SymmetricDifference(a dfa, b dfa) (dfa, error) {
+	return product(a, b, func(isAcceptingA bool, isAcceptingB bool) bool {
+		return isAcceptingA != isAcceptingB
+	})
+}
+
+/*
+	Builds the product automaton of two DFAs over the same alphabet.
+	Only the state pairs reachable from the starting states are created, and each pair is named '(p,q)',
+	with any backslash, comma or parenthesis in the names escaped by a backslash so that different pairs get different names.
+	A pair is accepting if isAccepting returns true for the acceptance of its states.
+	If either DFA fails validation or the alphabets do not match, then an empty DFA is returned.
+*/
+func product(a dfa, b dfa, isAccepting func(bool, bool) bool) (dfa, error) {
+	err := validatePair(&a, &b)
+	if err != nil {
+		return initializeDFA(), err
+	}
+
+	type pair struct {
+		a State
+		b State
+	}
+
+	start := pair{a.startingState, b.startingState}
+	visited := map[pair]bool{start: true}
+	queue := []pair{start}
+
+	var states []State
+	var acceptingStates []State
+	delta := make(Delta)
+
+	for len(queue) != 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		state := pairState(current.a, current.b)
+		states = append(states, state)
+		delta[state] = make(map[Symbol]State, len(a.alphabet))
+
+		if isAccepting(a.isStateAccepting(current.a), b.isStateAccepting(current.b)) {
+			acceptingStates = append(acceptingStates, state)
+		}
+
+		for _, symbol := range a.alphabet {
+			next := pair{a.delta[current.a][symbol], b.delta[current.b][symbol]}
+			delta[state][symbol] = pairState(next.a, next.b)
+
+			if !visited[next] {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	alphabet := append([]Symbol(nil), a.alphabet...)
+
+	return NewDFA(states, alphabet, delta, pairState(start.a, start.b), acceptingStates)
+}
+
+/*
+	Validates two DFAs that are combined and checks that they share an alphabet.
+*/
+func validatePair(a *dfa, b *dfa) error {
+	err := a.validate()
+	if err != nil {
+		return fmt.Errorf("the first DFA is invalid: %v", err)
+	}
+
+	err = b.validate()
+	if err != nil {
+		return fmt.Errorf("the second DFA is invalid: %v", err)
+	}
+
+	return checkAlphabets(a.alphabet, b.alphabet)
+}
+
+/*
+	Checks if two alphabets contain the same symbols, ignoring their order.
+*/
+func checkAlphabets(a []Symbol, b []Symbol) error {
+	for _, symbol := range a {
+		if !containsSymbol(b, symbol) {
+			return fmt.Errorf("the alphabets do not match, the symbol '%v' is only in the first alphabet", string(symbol))
+		}
+	}
+
+	for _, symbol := range b {
+		if !containsSymbol(a, symbol) {
+			return fmt.Errorf("the alphabets do not match, the symbol '%v' is only in the second alphabet", string(symbol))
+		}
+	}
+
+	return nil
+}
+
+/*
+	Checks if a symbol is in a symbol array.
+*/
+func containsSymbol(symbols []Symbol, symbol Symbol) bool {
+	for _, possibleSymbol := range symbols {
+		if possibleSymbol == symbol {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+	Names the product state of two states.
+*/
+func pairState(a State, b State) State {
+	return State(fmt.Sprintf("(%v,%v)", statename.Escape(string(a)), statename.Escape(string(b))))
+}
@@ -0,0 +1,163 @@
+package dfa
+
+import (
+	"flfa/internal/testutil"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var evenAs = dfa{
+	[]State{"e", "o"},
+	[]Symbol{'a', 'b'},
+	Delta{
+		"e": {
+			'a': "o",
+			'b': "e",
+		},
+		"o": {
+			'a': "e",
+			'b': "o",
+		},
+	},
+	State("e"),
+	[]State{"e"},
+}
+
+var endsInB = dfa{
+	[]State{"n", "y"},
+	[]Symbol{'b', 'a'},
+	Delta{
+		"n": {
+			'a': "n",
+			'b': "y",
+		},
+		"y": {
+			'a': "n",
+			'b': "y",
+		},
+	},
+	State("n"),
+	[]State{"y"},
+}
+
+func TestIntersect(t *testing.T) {
+	intersection, err := Intersect(evenAs, endsInB)
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, dfa{
+		[]State{"(e,n)", "(o,n)", "(e,y)", "(o,y)"},
+		[]Symbol{'a', 'b'},
+		Delta{
+			"(e,n)": {
+				'a': "(o,n)",
+				'b': "(e,y)",
+			},
+			"(o,n)": {
+				'a': "(e,n)",
+				'b': "(o,y)",
+			},
+			"(e,y)": {
+				'a': "(o,n)",
+				'b': "(e,y)",
+			},
+			"(o,y)": {
+				'a': "(e,n)",
+				'b': "(o,y)",
+			},
+		},
+		State("(e,n)"),
+		[]State{"(e,y)"},
+	}, intersection)
+}
+
+func TestProductLanguages(t *testing.T) {
+	var tests = []struct {
+		operation func(dfa, dfa) (dfa, error)
+		accepts   func(bool, bool) bool
+	}{
+		{Intersect, func(a bool, b bool) bool { return a && b }},
+		{Union, func(a bool, b bool) bool { return a || b }},
+		{Difference, func(a bool, b bool) bool { return a && !b }},
+		{SymmetricDifference, func(a bool, b bool) bool { return a != b }},
+	}
+
+	for _, tt := range tests {
+		product, err := tt.operation(evenAs, endsInB)
+		assert.Equal(t, nil, err)
+
+		for _, str := range testutil.Strings(evenAs.alphabet, 6) {
+			isEvenAs := strings.Count(str, "a")%2 == 0
+			isEndsInB := strings.HasSuffix(str, "b")
+
+			_, isAccepting, err := product.Solve(str)
+
+			assert.Equal(t, nil, err)
+			assert.Equal(t, tt.accepts(isEvenAs, isEndsInB), isAccepting, "string '%v'", str)
+		}
+	}
+}
+
+func TestProductStateNames(t *testing.T) {
+	// Without escaping, the pairs (a,b,c) and (a,b,c) of 'a,b' and 'c' and of 'a' and 'b,c' would have the same name
+	first := dfa{
+		[]State{"a,b", "a"},
+		[]Symbol{'x'},
+		Delta{
+			"a,b": {'x': "a"},
+			"a":   {'x': "a"},
+		},
+		State("a,b"),
+		[]State{"a"},
+	}
+
+	second := dfa{
+		[]State{"c", "b,c"},
+		[]Symbol{'x'},
+		Delta{
+			"c":   {'x': "b,c"},
+			"b,c": {'x': "b,c"},
+		},
+		State("c"),
+		[]State{"c"},
+	}
+
+	union, err := Union(first, second)
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []State{`(a\,b,c)`, `(a,b\,c)`}, union.states)
+	assert.Equal(t, Delta{
+		`(a\,b,c)`: {'x': `(a,b\,c)`},
+		`(a,b\,c)`: {'x': `(a,b\,c)`},
+	}, union.delta)
+	assert.Equal(t, []State{`(a\,b,c)`, `(a,b\,c)`}, union.acceptingStates)
+
+	assert.Equal(t, State(`(\(p\),\\)`), pairState("(p)", `\`))
+}
+
+func TestProductAlphabetMismatch(t *testing.T) {
+	binary := dfa{
+		[]State{"q0"},
+		[]Symbol{'0', '1'},
+		Delta{
+			"q0": {
+				'0': "q0",
+				'1': "q0",
+			},
+		},
+		State("q0"),
+		[]State{"q0"},
+	}
+
+	product, err := Union(evenAs, binary)
+
+	assert.Equal(t, fmt.Errorf("the alphabets do not match, the symbol 'a' is only in the first alphabet"), err)
+	assert.Equal(t, initializeDFA(), product)
+
+	product, err = Union(binary, evenAs)
+
+	assert.Equal(t, fmt.Errorf("the alphabets do not match, the symbol '0' is only in the first alphabet"), err)
+	assert.Equal(t, initializeDFA(), product)
+}
@@ -0,0 +1,237 @@
+package earley
+
+import (
+	"flfa/grammar"
+	"flfa/internal/diagnostic"
+	"flfa/lexer"
+)
+
+/*
+	An Earley parser, which parses with any context-free grammar, including ambiguous grammars and ones with empty productions.
+	The productions and nullable nonterminals are found once, since items refer to productions by their index.
+*/
+type Parser struct {
+	grammar     grammar.Grammar
+	lexer       *lexer.Lexer
+	productions []grammar.Production
+	nullable    grammar.SymbolSet
+}
+
+/*
+	A syntax error found by the parser, which callers can inspect with errors.As.
+	The token is the first one that no item could read, or the end token, with the end marker as its kind and an empty lexeme,
+	when the string ended too early. The expected terminals are the ones the items before the token could read.
+*/
+type SyntaxError struct {
+	Input    string
+	Token    lexer.Token
+	Expected []grammar.Symbol
+}
+
+/*
+	An Earley item, a production with a dot and the position where its recognition started.
+*/
+type item struct {
+	production int
+	dot        int
+	origin     int
+}
+
+/*
+	The items of every position, where the set at position k holds the items after reading k tokens.
+*/
+type chart struct {
+	sets    [][]item
+	isAdded []map[item]bool
+}
+
+/*
+	Creates an Earley parser for a grammar.
+	The parser reads the tokens of the lexer, or one token per character if the lexer is nil.
+*/
+func NewParser(g grammar.Grammar, lexer *lexer.Lexer) Parser {
+	return Parser{g, lexer, g.Productions(), g.Nullable()}
+}
+
+/*
+	Checks if a string is in the grammar's language.
+	Only errors from the lexer are returned.
+*/
+func (parser *Parser) Recognize(str string) (bool, error) {
+	tokens, err := parser.tokenize(str)
+	if err != nil {
+		return false, err
+	}
+
+	chart := parser.recognize(tokens)
+
+	return parser.isAccepted(chart, len(tokens)), nil
+}
+
+/*
+	Parses a string into a shared packed parse forest holding every parse tree of the string.
+	If the string is not in the grammar's language, then a *SyntaxError at the furthest position reached is returned.
+*/
+func (parser *Parser) Parse(str string) (*Forest, error) {
+	tokens, err := parser.tokenize(str)
+	if err != nil {
+		return nil, err
+	}
+
+	chart := parser.recognize(tokens)
+
+	if !parser.isAccepted(chart, len(tokens)) {
+		return nil, parser.syntaxError(str, tokens, chart)
+	}
+
+	return parser.forest(str, tokens, chart), nil
+}
+
+func (err *SyntaxError) Error() string {
+	return diagnostic.Message(err.Token, "", err.Expected)
+}
+
+/*
+	Renders the line of the input holding the error with carets under the offending token.
+*/
+func (err *SyntaxError) Snippet() string {
+	return lexer.Snippet(err.Input, err.Token)
+}
+
+/*
+	Splits a string into tokens with the parser's lexer, or into characters if the parser has no lexer.
+*/
+func (parser *Parser) tokenize(str string) ([]lexer.Token, error) {
+	if parser.lexer != nil {
+		return parser.lexer.Tokenize(str)
+	}
+
+	return lexer.Runes(str), nil
+}
+
+/*
+	Fills in the chart of the tokens by predicting, scanning and completing items.
+	A nullable nonterminal is skipped over as soon as it is predicted, so that empty productions need no special completion.
+*/
+func (parser *Parser) recognize(tokens []lexer.Token) chart {
+	chart := chart{make([][]item, len(tokens)+1), make([]map[item]bool, len(tokens)+1)}
+	for k := range chart.isAdded {
+		chart.isAdded[k] = make(map[item]bool)
+	}
+
+	for i, production := range parser.productions {
+		if production.Head == parser.grammar.Start() {
+			chart.add(0, item{i, 0, 0})
+		}
+	}
+
+	for k := 0; k <= len(tokens); k++ {
+		for i := 0; i < len(chart.sets[k]); i++ {
+			current := chart.sets[k][i]
+			body := parser.productions[current.production].Body
+
+			if current.dot == len(body) {
+				head := parser.productions[current.production].Head
+
+				for _, waiting := range chart.sets[current.origin] {
+					if next, ok := parser.next(waiting); ok && next == head {
+						chart.add(k, item{waiting.production, waiting.dot + 1, waiting.origin})
+					}
+				}
+
+				continue
+			}
+
+			symbol := body[current.dot]
+
+			if parser.grammar.IsNonterminal(symbol) {
+				for j, production := range parser.productions {
+					if production.Head == symbol {
+						chart.add(k, item{j, 0, k})
+					}
+				}
+
+				if parser.nullable[symbol] {
+					chart.add(k, item{current.production, current.dot + 1, current.origin})
+				}
+
+				continue
+			}
+
+			if k < len(tokens) && grammar.Symbol(tokens[k].Kind) == symbol {
+				chart.add(k+1, item{current.production, current.dot + 1, current.origin})
+			}
+		}
+	}
+
+	return chart
+}
+
+/*
+	Adds an item to a set of the chart unless it is already there.
+*/
+func (chart *chart) add(k int, item item) {
+	if !chart.isAdded[k][item] {
+		chart.isAdded[k][item] = true
+		chart.sets[k] = append(chart.sets[k], item)
+	}
+}
+
+/*
+	Gets the symbol after the dot of an item, if there is one.
+*/
+func (parser *Parser) next(item item) (grammar.Symbol, bool) {
+	body := parser.productions[item.production].Body
+	if item.dot == len(body) {
+		return "", false
+	}
+
+	return body[item.dot], true
+}
+
+/*
+	Checks if a production of the start symbol was recognized from the first to the last token.
+*/
+func (parser *Parser) isAccepted(chart chart, n int) bool {
+	for _, item := range chart.sets[n] {
+		production := parser.productions[item.production]
+		if production.Head == parser.grammar.Start() && item.origin == 0 && item.dot == len(production.Body) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+	Creates the syntax error at the furthest position any item reached, expecting the terminals the items there could read.
+*/
+func (parser *Parser) syntaxError(str string, tokens []lexer.Token, chart chart) *SyntaxError {
+	k := len(chart.sets) - 1
+	for len(chart.sets[k]) == 0 {
+		k--
+	}
+
+	token := lexer.Token{Kind: lexer.Kind(grammar.EndMarker), Pos: lexer.EndOf(str)}
+	if k < len(tokens) {
+		token = tokens[k]
+	}
+
+	var expected []grammar.Symbol
+	isExpected := make(grammar.SymbolSet)
+	for _, item := range chart.sets[k] {
+		if symbol, ok := parser.next(item); ok && parser.grammar.IsTerminal(symbol) && !isExpected[symbol] {
+			isExpected[symbol] = true
+			expected = append(expected, symbol)
+		}
+	}
+
+	// The string could have ended where a production of the start symbol was recognized from the first token
+	if parser.isAccepted(chart, k) {
+		expected = append(expected, grammar.EndMarker)
+	}
+
+	diagnostic.SortExpected(expected)
+
+	return &SyntaxError{str, token, expected}
+}
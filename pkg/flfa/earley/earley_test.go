@@ -0,0 +1,175 @@
+package earley
+
+import (
+	"errors"
+	"flfa/grammar"
+	"flfa/internal/testutil"
+	"flfa/lexer"
+	"flfa/ll1"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/*
+	Creates the ambiguous grammar of sums and products without precedence.
+*/
+func newAmbiguousGrammar(t *testing.T) grammar.Grammar {
+	t.Helper()
+
+	g, err := grammar.NewGrammar(
+		[]grammar.Symbol{"E"},
+		[]grammar.Symbol{"+", "*", "(", ")", "id"},
+		"E",
+		[]grammar.Production{
+			{Head: "E", Body: []grammar.Symbol{"E", "+", "E"}},
+			{Head: "E", Body: []grammar.Symbol{"E", "*", "E"}},
+			{Head: "E", Body: []grammar.Symbol{"(", "E", ")"}},
+			{Head: "E", Body: []grammar.Symbol{"id"}},
+		},
+	)
+	require.NoError(t, err)
+
+	return g
+}
+
+func TestRecognize(t *testing.T) {
+	// Palindromes are not LL(1) nor LR, and need the empty production
+	g, err := grammar.NewGrammar(
+		[]grammar.Symbol{"P"},
+		[]grammar.Symbol{"a", "b"},
+		"P",
+		[]grammar.Production{
+			{Head: "P", Body: []grammar.Symbol{"a", "P", "a"}},
+			{Head: "P", Body: []grammar.Symbol{"b", "P", "b"}},
+			{Head: "P", Body: []grammar.Symbol{"a"}},
+			{Head: "P", Body: []grammar.Symbol{"b"}},
+			{Head: "P", Body: []grammar.Symbol{}},
+		},
+	)
+	assert.Equal(t, nil, err)
+
+	parser := NewParser(g, nil)
+
+	var tests = []struct {
+		str  string
+		want bool
+	}{
+		{"", true},
+		{"a", true},
+		{"abba", true},
+		{"abaabaab", false},
+		{"ababbaba", true},
+		{"ab", false},
+		{"abc", false},
+	}
+
+	for _, tt := range tests {
+		isAccepted, err := parser.Recognize(tt.str)
+		assert.Equal(t, nil, err, "string '%v'", tt.str)
+		assert.Equal(t, tt.want, isAccepted, "string '%v'", tt.str)
+	}
+
+	lex := testutil.ExpressionLexer(t)
+	parser = NewParser(newAmbiguousGrammar(t), &lex)
+
+	_, err = parser.Recognize("a # b")
+	assert.EqualError(t, err, "the character '#' at 1:3 does not start any token")
+}
+
+func TestRecognizeNullable(t *testing.T) {
+	// A nullable nonterminal that is only nullable through another one, and a nullable start symbol
+	g, err := grammar.NewGrammar(
+		[]grammar.Symbol{"S", "A", "B"},
+		[]grammar.Symbol{"a", "b"},
+		"S",
+		[]grammar.Production{
+			{Head: "S", Body: []grammar.Symbol{"A", "B", "A", "b"}},
+			{Head: "S", Body: []grammar.Symbol{"B"}},
+			{Head: "A", Body: []grammar.Symbol{"a"}},
+			{Head: "A", Body: []grammar.Symbol{}},
+			{Head: "B", Body: []grammar.Symbol{"A", "A"}},
+		},
+	)
+	assert.Equal(t, nil, err)
+
+	parser := NewParser(g, nil)
+
+	for _, str := range []string{"", "a", "aa", "b", "ab", "aab", "aaaab"} {
+		isAccepted, err := parser.Recognize(str)
+		assert.Equal(t, nil, err, "string '%v'", str)
+		assert.True(t, isAccepted, "string '%v'", str)
+	}
+
+	for _, str := range []string{"aaa", "ba", "aaaaab", "bb"} {
+		isAccepted, err := parser.Recognize(str)
+		assert.Equal(t, nil, err, "string '%v'", str)
+		assert.False(t, isAccepted, "string '%v'", str)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	lex := testutil.ExpressionLexer(t)
+	parser := NewParser(newAmbiguousGrammar(t), &lex)
+
+	var tests = []struct {
+		str  string
+		want string
+	}{
+		{"a + * b", "1:5: unexpected '*', expected '(' or 'id'"},
+		{"(a + b", "1:7: unexpected end of the string, expected ')' or '*' or '+'"},
+		{"a b", "1:3: unexpected 'b', expected '*' or '+' or end of the string"},
+		{"", "1:1: unexpected end of the string, expected '(' or 'id'"},
+	}
+
+	for _, tt := range tests {
+		_, err := parser.Parse(tt.str)
+		assert.EqualError(t, err, tt.want, "string '%v'", tt.str)
+	}
+
+	// The furthest position is reported, even when an earlier token could have been read otherwise
+	_, err := parser.Parse("(a +\n  b))")
+
+	var syntaxError *SyntaxError
+	assert.True(t, errors.As(err, &syntaxError))
+	assert.Equal(t, lexer.Token{Kind: ")", Lexeme: ")", Pos: lexer.Position{Offset: 9, Line: 2, Column: 5}}, syntaxError.Token)
+	assert.Equal(t, []grammar.Symbol{"*", "+", grammar.EndMarker}, syntaxError.Expected)
+	assert.Equal(t, "  b))\n    ^\n", syntaxError.Snippet())
+}
+
+func TestParseSameTreeAsLL1(t *testing.T) {
+	g, err := grammar.NewGrammar(
+		[]grammar.Symbol{"E", "E'", "T", "T'", "F"},
+		[]grammar.Symbol{"+", "*", "(", ")", "id"},
+		"E",
+		[]grammar.Production{
+			{Head: "E", Body: []grammar.Symbol{"T", "E'"}},
+			{Head: "E'", Body: []grammar.Symbol{"+", "T", "E'"}},
+			{Head: "E'", Body: []grammar.Symbol{}},
+			{Head: "T", Body: []grammar.Symbol{"F", "T'"}},
+			{Head: "T'", Body: []grammar.Symbol{"*", "F", "T'"}},
+			{Head: "T'", Body: []grammar.Symbol{}},
+			{Head: "F", Body: []grammar.Symbol{"(", "E", ")"}},
+			{Head: "F", Body: []grammar.Symbol{"id"}},
+		},
+	)
+	assert.Equal(t, nil, err)
+
+	lex := testutil.ExpressionLexer(t)
+
+	ll1Parser, err := ll1.NewLL1FromGrammarWithLexer(g, lex)
+	assert.Equal(t, nil, err)
+
+	parser := NewParser(g, &lex)
+
+	for _, str := range []string{"a", "a + b * c ", " (a+b) * c", "((a))"} {
+		want, err := ll1Parser.Parse(str)
+		assert.Equal(t, nil, err, "string '%v'", str)
+
+		forest, err := parser.Parse(str)
+		assert.Equal(t, nil, err, "string '%v'", str)
+		assert.False(t, forest.IsAmbiguous(), "string '%v'", str)
+		assert.Equal(t, want, forest.Trees(0)[0], "string '%v'", str)
+	}
+}
@@ -0,0 +1,271 @@
+package earley
+
+import (
+	"flfa/grammar"
+	"flfa/lexer"
+	"flfa/parsetree"
+)
+
+/*
+	A shared packed parse forest, holding every parse tree of a string.
+	Subtrees are shared between trees, so the forest stays polynomial even when the string has exponentially many trees.
+*/
+type Forest struct {
+	Root *ForestNode
+}
+
+/*
+	A node of a forest, standing for every derivation of a symbol over the tokens from Start up to End.
+	A terminal node holds the token it matched, and a nonterminal node has a family for each way it was derived.
+	The span is the text of the tokens in the string, or an empty span before the next token if the node derives the empty string.
+*/
+type ForestNode struct {
+	Symbol   grammar.Symbol
+	Terminal bool
+	Start    int
+	End      int
+	Token    lexer.Token
+	Span     parsetree.Span
+	Families []Family
+}
+
+/*
+	A packed node of a forest, a production and the nodes its body was derived from.
+*/
+type Family struct {
+	Production grammar.Production
+	Children   []*ForestNode
+}
+
+/*
+	A symbol recognized over the tokens from start up to end.
+*/
+type key struct {
+	symbol grammar.Symbol
+	start  int
+	end    int
+}
+
+/*
+	Builds the forest of an accepted string from the completed items of its chart.
+*/
+func (parser *Parser) forest(str string, tokens []lexer.Token, chart chart) *Forest {
+	// The productions recognized for every symbol and pair of positions
+	completed := make(map[key][]int)
+	for k, set := range chart.sets {
+		for _, item := range set {
+			if item.dot == len(parser.productions[item.production].Body) {
+				key := key{parser.productions[item.production].Head, item.origin, k}
+				completed[key] = append(completed[key], item.production)
+			}
+		}
+	}
+
+	builder := forestBuilder{parser, str, tokens, completed, make(map[key]*ForestNode)}
+
+	return &Forest{builder.node(key{parser.grammar.Start(), 0, len(tokens)})}
+}
+
+/*
+	Builds the nodes of a forest, sharing every node between the families it is a child of.
+*/
+type forestBuilder struct {
+	parser    *Parser
+	str       string
+	tokens    []lexer.Token
+	completed map[key][]int
+	nodes     map[key]*ForestNode
+}
+
+/*
+	Gets the node of a symbol over a range of tokens, building it and its descendants if it is new.
+	The node is stored before its families are built, so that a cyclic grammar gives a cyclic forest.
+*/
+func (builder *forestBuilder) node(key key) *ForestNode {
+	if node, ok := builder.nodes[key]; ok {
+		return node
+	}
+
+	node := &ForestNode{Symbol: key.symbol, Terminal: builder.parser.grammar.IsTerminal(key.symbol), Start: key.start, End: key.end}
+	node.Span = builder.span(key.start, key.end)
+	builder.nodes[key] = node
+
+	if node.Terminal {
+		node.Token = builder.tokens[key.start]
+		return node
+	}
+
+	for _, i := range builder.completed[key] {
+		production := builder.parser.productions[i]
+
+		for _, children := range builder.split(production.Body, key.start, key.end) {
+			node.Families = append(node.Families, Family{production, children})
+		}
+	}
+
+	return node
+}
+
+/*
+	Finds every way the symbols can be derived one after another over a range of tokens.
+*/
+func (builder *forestBuilder) split(symbols []grammar.Symbol, start int, end int) [][]*ForestNode {
+	if len(symbols) == 0 {
+		if start == end {
+			return [][]*ForestNode{{}}
+		}
+
+		return nil
+	}
+
+	var splits [][]*ForestNode
+
+	if builder.parser.grammar.IsTerminal(symbols[0]) {
+		if start < end && grammar.Symbol(builder.tokens[start].Kind) == symbols[0] {
+			for _, rest := range builder.split(symbols[1:], start+1, end) {
+				splits = append(splits, append([]*ForestNode{builder.node(key{symbols[0], start, start + 1})}, rest...))
+			}
+		}
+
+		return splits
+	}
+
+	for middle := start; middle <= end; middle++ {
+		first := key{symbols[0], start, middle}
+		if _, ok := builder.completed[first]; !ok {
+			continue
+		}
+
+		rest := builder.split(symbols[1:], middle, end)
+		if len(rest) == 0 {
+			continue
+		}
+
+		node := builder.node(first)
+		for _, children := range rest {
+			splits = append(splits, append([]*ForestNode{node}, children...))
+		}
+	}
+
+	return splits
+}
+
+/*
+	Gets the span of the text of a range of tokens.
+*/
+func (builder *forestBuilder) span(start int, end int) parsetree.Span {
+	offset := len(builder.str)
+	if start < len(builder.tokens) {
+		offset = builder.tokens[start].Pos.Offset
+	}
+
+	if start == end {
+		return parsetree.Span{Start: offset, End: offset}
+	}
+
+	last := builder.tokens[end-1]
+
+	return parsetree.Span{Start: offset, End: last.Pos.Offset + len(last.Lexeme)}
+}
+
+/*
+	Checks if the string has more than one parse tree, that is if a node of the forest has more than one family.
+*/
+func (forest *Forest) IsAmbiguous() bool {
+	isVisited := make(map[*ForestNode]bool)
+	stack := []*ForestNode{forest.Root}
+
+	for len(stack) != 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if isVisited[node] {
+			continue
+		}
+		isVisited[node] = true
+
+		if len(node.Families) > 1 {
+			return true
+		}
+
+		for _, family := range node.Families {
+			stack = append(stack, family.Children...)
+		}
+	}
+
+	return false
+}
+
+/*
+	Enumerates the parse trees of the forest, in the order of the families, stopping after the given number of trees.
+	If the limit is not positive, then every tree is enumerated.
+	A cyclic grammar derives a string in infinitely many ways, so only trees where no node is its own descendant are enumerated.
+	The trees have the same shape and spans as the trees of the LL(1) and LR parsers, and may share subtrees.
+*/
+func (forest *Forest) Trees(limit int) []*parsetree.Node {
+	trees := forest.Root.trees(make(map[*ForestNode]bool), limit)
+	for _, tree := range trees {
+		tree.FitSpans()
+	}
+
+	return trees
+}
+
+/*
+	Enumerates the trees of a node, without the nodes on the path from the root.
+*/
+func (node *ForestNode) trees(path map[*ForestNode]bool, limit int) []*parsetree.Node {
+	if node.Terminal {
+		return []*parsetree.Node{{Symbol: string(node.Symbol), Terminal: true, Lexeme: node.Token.Lexeme, Span: node.Span}}
+	}
+
+	if path[node] {
+		return nil
+	}
+
+	path[node] = true
+	defer delete(path, node)
+
+	var trees []*parsetree.Node
+
+	for _, family := range node.Families {
+		// The children's trees are combined in every way, the last child changing fastest
+		combinations := [][]*parsetree.Node{{}}
+
+		for _, child := range family.Children {
+			childTrees := child.trees(path, limit)
+
+			var next [][]*parsetree.Node
+			for _, combination := range combinations {
+				for _, childTree := range childTrees {
+					next = append(next, append(append([]*parsetree.Node(nil), combination...), childTree))
+
+					if limit > 0 && len(next) == limit {
+						break
+					}
+				}
+
+				if limit > 0 && len(next) == limit {
+					break
+				}
+			}
+
+			combinations = next
+		}
+
+		for _, children := range combinations {
+			tree := &parsetree.Node{Symbol: string(node.Symbol), Span: node.Span}
+			if len(children) != 0 {
+				tree.Children = children
+			}
+
+			trees = append(trees, tree)
+
+			if limit > 0 && len(trees) == limit {
+				return trees
+			}
+		}
+	}
+
+	return trees
+}
@@ -0,0 +1,139 @@
+package earley
+
+import (
+	"flfa/grammar"
+	"flfa/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrees(t *testing.T) {
+	lex := testutil.ExpressionLexer(t)
+	parser := NewParser(newAmbiguousGrammar(t), &lex)
+
+	forest, err := parser.Parse("a + b * c")
+	assert.Equal(t, nil, err)
+	assert.True(t, forest.IsAmbiguous())
+
+	trees := forest.Trees(0)
+	assert.Equal(t, 2, len(trees))
+	assert.Equal(t, `E [0,9)
+  E [0,1)
+    id "a" [0,1)
+  + "+" [2,3)
+  E [4,9)
+    E [4,5)
+      id "b" [4,5)
+    * "*" [6,7)
+    E [8,9)
+      id "c" [8,9)
+`, trees[1].String())
+	assert.Equal(t, `E [0,9)
+  E [0,5)
+    E [0,1)
+      id "a" [0,1)
+    + "+" [2,3)
+    E [4,5)
+      id "b" [4,5)
+  * "*" [6,7)
+  E [8,9)
+    id "c" [8,9)
+`, trees[0].String())
+
+	// The number of trees of a chain of operators is a Catalan number
+	var tests = []struct {
+		str  string
+		want int
+	}{
+		{"a", 1},
+		{"(a + b)", 1},
+		{"a + b + c", 2},
+		{"a + b + c + d", 5},
+		{"a + (b + c) + d * e", 5},
+		{"a + b + c + d + e + f", 42},
+	}
+
+	for _, tt := range tests {
+		forest, err := parser.Parse(tt.str)
+		assert.Equal(t, nil, err, "string '%v'", tt.str)
+		assert.Equal(t, tt.want, len(forest.Trees(0)), "string '%v'", tt.str)
+		assert.Equal(t, tt.want > 1, forest.IsAmbiguous(), "string '%v'", tt.str)
+	}
+
+	forest, err = parser.Parse("a + b + c + d + e + f")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 10, len(forest.Trees(10)))
+}
+
+func TestTreesEmpty(t *testing.T) {
+	// The single 'a' may be derived by any of the three nonterminals
+	g, err := grammar.NewGrammar(
+		[]grammar.Symbol{"S", "A"},
+		[]grammar.Symbol{"a"},
+		"S",
+		[]grammar.Production{
+			{Head: "S", Body: []grammar.Symbol{"A", "A", "A"}},
+			{Head: "A", Body: []grammar.Symbol{"a"}},
+			{Head: "A", Body: []grammar.Symbol{}},
+		},
+	)
+	assert.Equal(t, nil, err)
+
+	parser := NewParser(g, nil)
+
+	forest, err := parser.Parse("a")
+	assert.Equal(t, nil, err)
+
+	trees := forest.Trees(0)
+	assert.Equal(t, 3, len(trees))
+	assert.Equal(t, `S [0,1)
+  A [0,0)
+    ε
+  A [0,0)
+    ε
+  A [0,1)
+    a "a" [0,1)
+`, trees[0].String())
+	assert.Equal(t, `S [0,1)
+  A [0,1)
+    a "a" [0,1)
+  A [1,1)
+    ε
+  A [1,1)
+    ε
+`, trees[2].String())
+
+	forest, err = parser.Parse("")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(forest.Trees(0)))
+}
+
+func TestTreesCyclic(t *testing.T) {
+	// S derives itself, so only the trees without a repeated node are enumerated
+	g, err := grammar.NewGrammar(
+		[]grammar.Symbol{"S", "T"},
+		[]grammar.Symbol{"a"},
+		"S",
+		[]grammar.Production{
+			{Head: "S", Body: []grammar.Symbol{"S"}},
+			{Head: "S", Body: []grammar.Symbol{"T"}},
+			{Head: "T", Body: []grammar.Symbol{"S"}},
+			{Head: "T", Body: []grammar.Symbol{"a"}},
+		},
+	)
+	assert.Equal(t, nil, err)
+
+	parser := NewParser(g, nil)
+
+	forest, err := parser.Parse("a")
+	assert.Equal(t, nil, err)
+	assert.True(t, forest.IsAmbiguous())
+
+	trees := forest.Trees(0)
+	assert.Equal(t, 1, len(trees))
+	assert.Equal(t, `S [0,1)
+  T [0,1)
+    a "a" [0,1)
+`, trees[0].String())
+}
@@ -0,0 +1,183 @@
+package grammar
+
+import (
+	"flfa/internal/sliceutil"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type Symbol string
+
+/*
+	The terminal that follows the start symbol at the end of the input.
+*/
+const EndMarker Symbol = "$"
+
+/*
+	A production that rewrites the head nonterminal into the body, where an empty body is ε.
+*/
+type Production struct {
+	Head Symbol
+	Body []Symbol
+}
+
+/*
+	A set of symbols.
+*/
+type SymbolSet map[Symbol]bool
+
+/*
+	A context-free grammar.
+*/
+type Grammar struct {
+	nonterminals []Symbol
+	terminals    []Symbol
+	start        Symbol
+	productions  []Production
+}
+
+/*
+	Creates a grammar and validates it.
+	If the grammar fails validation, then an empty grammar is returned.
+*/
+func NewGrammar(nonterminals []Symbol, terminals []Symbol, start Symbol, productions []Production) (Grammar, error) {
+	grammar := Grammar{nonterminals, terminals, start, productions}
+
+	err := grammar.validate()
+	if err != nil {
+		return Grammar{}, err
+	}
+
+	return grammar, nil
+}
+
+/*
+	Gets a copy of the grammar's nonterminals.
+*/
+func (grammar *Grammar) Nonterminals() []Symbol {
+	return append([]Symbol(nil), grammar.nonterminals...)
+}
+
+/*
+	Gets a copy of the grammar's terminals.
+*/
+func (grammar *Grammar) Terminals() []Symbol {
+	return append([]Symbol(nil), grammar.terminals...)
+}
+
+/*
+	Gets the grammar's start symbol.
+*/
+func (grammar *Grammar) Start() Symbol {
+	return grammar.start
+}
+
+/*
+	Gets a copy of the grammar's productions.
+*/
+func (grammar *Grammar) Productions() []Production {
+	return append([]Production(nil), grammar.productions...)
+}
+
+/*
+	Gets the productions of a nonterminal in the order they were given.
+*/
+func (grammar *Grammar) ProductionsOf(head Symbol) []Production {
+	var productions []Production
+
+	for _, production := range grammar.productions {
+		if production.Head == head {
+			productions = append(productions, production)
+		}
+	}
+
+	return productions
+}
+
+/*
+	Checks if a symbol is one of the grammar's nonterminals.
+*/
+func (grammar *Grammar) IsNonterminal(symbol Symbol) bool {
+	return sliceutil.Contains(grammar.nonterminals, symbol)
+}
+
+/*
+	Checks if a symbol is one of the grammar's terminals.
+*/
+func (grammar *Grammar) IsTerminal(symbol Symbol) bool {
+	return sliceutil.Contains(grammar.terminals, symbol)
+}
+
+/*
+	Formats the grammar with one line per production.
+*/
+func (grammar Grammar) String() string {
+	var builder strings.Builder
+
+	for _, production := range grammar.productions {
+		builder.WriteString(production.String())
+		builder.WriteRune('\n')
+	}
+
+	return builder.String()
+}
+
+/*
+	Formats the production as 'A -> b C', where an empty body is written 'ε'.
+*/
+func (production Production) String() string {
+	if len(production.Body) == 0 {
+		return fmt.Sprintf("%v -> ε", production.Head)
+	}
+
+	body := make([]string, len(production.Body))
+	for i, symbol := range production.Body {
+		body[i] = string(symbol)
+	}
+
+	return fmt.Sprintf("%v -> %v", production.Head, strings.Join(body, " "))
+}
+
+/*
+	Lists the symbols of the set sorted by name.
+*/
+func (set SymbolSet) Sorted() []Symbol {
+	symbols := make([]Symbol, 0, len(set))
+	for symbol := range set {
+		symbols = append(symbols, symbol)
+	}
+
+	sort.Slice(symbols, func(i, j int) bool { return symbols[i] < symbols[j] })
+
+	return symbols
+}
+
+/*
+	Validates the grammar.
+*/
+func (grammar *Grammar) validate() error {
+	for _, nonterminal := range grammar.nonterminals {
+		if sliceutil.Contains(grammar.terminals, nonterminal) {
+			return fmt.Errorf("the symbol '%v' cannot be both a terminal and a nonterminal", nonterminal)
+		}
+	}
+
+	if !grammar.IsNonterminal(grammar.start) {
+		return fmt.Errorf("the start symbol '%v' is not in the nonterminals", grammar.start)
+	}
+
+	for _, production := range grammar.productions {
+		if !grammar.IsNonterminal(production.Head) {
+			return fmt.Errorf("the head of the production '%v' is not in the nonterminals", production)
+		}
+
+		for _, symbol := range production.Body {
+			if !grammar.IsNonterminal(symbol) && !grammar.IsTerminal(symbol) {
+				return fmt.Errorf("the symbol '%v' in the production '%v' is neither a terminal nor a nonterminal", symbol, production)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package grammar
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGrammar(t *testing.T) {
+	var tests = []struct {
+		nonterminals []Symbol
+		terminals    []Symbol
+		start        Symbol
+		productions  []Production
+		want         error
+	}{
+		{
+			[]Symbol{"S"}, []Symbol{"a"}, "A",
+			[]Production{{"S", []Symbol{"a"}}},
+			fmt.Errorf("the start symbol 'A' is not in the nonterminals"),
+		},
+		{
+			[]Symbol{"S", "a"}, []Symbol{"a"}, "S",
+			[]Production{{"S", []Symbol{"a"}}},
+			fmt.Errorf("the symbol 'a' cannot be both a terminal and a nonterminal"),
+		},
+		{
+			[]Symbol{"S"}, []Symbol{"a"}, "S",
+			[]Production{{"A", []Symbol{"a"}}},
+			fmt.Errorf("the head of the production 'A -> a' is not in the nonterminals"),
+		},
+		{
+			[]Symbol{"S"}, []Symbol{"a"}, "S",
+			[]Production{{"S", []Symbol{"a", "b"}}},
+			fmt.Errorf("the symbol 'b' in the production 'S -> a b' is neither a terminal nor a nonterminal"),
+		},
+	}
+
+	for _, tt := range tests {
+		g, err := NewGrammar(tt.nonterminals, tt.terminals, tt.start, tt.productions)
+
+		assert.Equal(t, tt.want, err)
+		assert.Equal(t, Grammar{}, g)
+	}
+
+	assert.Equal(t, "S -> t $\nt -> r T\n", Grammar{productions: []Production{{"S", []Symbol{"t", "$"}}, {"t", []Symbol{"r", "T"}}}}.String())
+	assert.Equal(t, "T -> ε", Production{"T", nil}.String())
+}
@@ -0,0 +1,141 @@
+package grammar
+
+/*
+	Finds the nonterminals that derive the empty string.
+*/
+func (grammar *Grammar) Nullable() SymbolSet {
+	nullable := make(SymbolSet)
+
+	for isChanged := true; isChanged; {
+		isChanged = false
+
+		for _, production := range grammar.productions {
+			if nullable[production.Head] || !grammar.isNullableString(production.Body, nullable) {
+				continue
+			}
+
+			nullable[production.Head] = true
+			isChanged = true
+		}
+	}
+
+	return nullable
+}
+
+/*
+	Finds the FIRST set of every terminal and nonterminal, the terminals that can begin a string derived from it.
+	The FIRST set of a terminal is the terminal itself.
+*/
+func (grammar *Grammar) First() map[Symbol]SymbolSet {
+	nullable := grammar.Nullable()
+	first := make(map[Symbol]SymbolSet)
+
+	for _, terminal := range grammar.terminals {
+		first[terminal] = SymbolSet{terminal: true}
+	}
+
+	for _, nonterminal := range grammar.nonterminals {
+		first[nonterminal] = make(SymbolSet)
+	}
+
+	for isChanged := true; isChanged; {
+		isChanged = false
+
+		for _, production := range grammar.productions {
+			for terminal := range grammar.firstOfString(production.Body, nullable, first) {
+				if !first[production.Head][terminal] {
+					first[production.Head][terminal] = true
+					isChanged = true
+				}
+			}
+		}
+	}
+
+	return first
+}
+
+/*
+	Finds the FOLLOW set of every nonterminal, the terminals that can come right after it in a sentential form.
+	The FOLLOW set of the start symbol contains the end marker.
+*/
+func (grammar *Grammar) Follow() map[Symbol]SymbolSet {
+	nullable := grammar.Nullable()
+	first := grammar.First()
+	follow := make(map[Symbol]SymbolSet)
+
+	for _, nonterminal := range grammar.nonterminals {
+		follow[nonterminal] = make(SymbolSet)
+	}
+	follow[grammar.start][EndMarker] = true
+
+	for isChanged := true; isChanged; {
+		isChanged = false
+
+		for _, production := range grammar.productions {
+			for i, symbol := range production.Body {
+				if !grammar.IsNonterminal(symbol) {
+					continue
+				}
+
+				rest := production.Body[i+1:]
+
+				added := grammar.firstOfString(rest, nullable, first)
+				if grammar.isNullableString(rest, nullable) {
+					for terminal := range follow[production.Head] {
+						added[terminal] = true
+					}
+				}
+
+				for terminal := range added {
+					if !follow[symbol][terminal] {
+						follow[symbol][terminal] = true
+						isChanged = true
+					}
+				}
+			}
+		}
+	}
+
+	return follow
+}
+
+/*
+	Finds the FIRST set of a string of symbols and if the string derives the empty string.
+*/
+func (grammar *Grammar) FirstOf(symbols []Symbol) (SymbolSet, bool) {
+	nullable := grammar.Nullable()
+
+	return grammar.firstOfString(symbols, nullable, grammar.First()), grammar.isNullableString(symbols, nullable)
+}
+
+/*
+	Finds the FIRST set of a string of symbols from the FIRST sets of the symbols.
+*/
+func (grammar *Grammar) firstOfString(symbols []Symbol, nullable SymbolSet, first map[Symbol]SymbolSet) SymbolSet {
+	set := make(SymbolSet)
+
+	for _, symbol := range symbols {
+		for terminal := range first[symbol] {
+			set[terminal] = true
+		}
+
+		if !nullable[symbol] {
+			break
+		}
+	}
+
+	return set
+}
+
+/*
+	Checks if every symbol of a string derives the empty string.
+*/
+func (grammar *Grammar) isNullableString(symbols []Symbol, nullable SymbolSet) bool {
+	for _, symbol := range symbols {
+		if !nullable[symbol] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,46 @@
+package grammar_test
+
+import (
+	"flfa/grammar"
+	"flfa/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNullable(t *testing.T) {
+	g := testutil.ArithmeticGrammar(t)
+
+	assert.Equal(t, grammar.SymbolSet{"T": true, "R": true}, g.Nullable())
+}
+
+func TestFirst(t *testing.T) {
+	g := testutil.ArithmeticGrammar(t)
+	first := g.First()
+
+	assert.Equal(t, []grammar.Symbol{"(", "d"}, first["S"].Sorted())
+	assert.Equal(t, []grammar.Symbol{"(", "d"}, first["t"].Sorted())
+	assert.Equal(t, []grammar.Symbol{"+", "-"}, first["T"].Sorted())
+	assert.Equal(t, []grammar.Symbol{"*", "/"}, first["R"].Sorted())
+	assert.Equal(t, []grammar.Symbol{"+"}, first["+"].Sorted())
+
+	set, isNullable := g.FirstOf([]grammar.Symbol{"R", "T", ")"})
+	assert.Equal(t, []grammar.Symbol{")", "*", "+", "-", "/"}, set.Sorted())
+	assert.False(t, isNullable)
+
+	set, isNullable = g.FirstOf([]grammar.Symbol{"R", "T"})
+	assert.Equal(t, []grammar.Symbol{"*", "+", "-", "/"}, set.Sorted())
+	assert.True(t, isNullable)
+}
+
+func TestFollow(t *testing.T) {
+	g := testutil.ArithmeticGrammar(t)
+	follow := g.Follow()
+
+	assert.Equal(t, []grammar.Symbol{"$"}, follow["S"].Sorted())
+	assert.Equal(t, []grammar.Symbol{"$", ")"}, follow["t"].Sorted())
+	assert.Equal(t, []grammar.Symbol{"$", ")"}, follow["T"].Sorted())
+	assert.Equal(t, []grammar.Symbol{"$", ")", "+", "-"}, follow["r"].Sorted())
+	assert.Equal(t, []grammar.Symbol{"$", ")", "+", "-"}, follow["R"].Sorted())
+	assert.Equal(t, []grammar.Symbol{"$", ")", "*", "+", "-", "/"}, follow["v"].Sorted())
+}
@@ -0,0 +1,320 @@
+package grammar
+
+import (
+	"flfa/internal/sliceutil"
+	"fmt"
+)
+
+/*
+	Removes direct and indirect left recursion, so that no nonterminal derives a string starting with itself.
+	Nonterminals are ordered as given, every production 'Ai -> Aj γ' with j < i is expanded with the productions of Aj,
+	and then the direct left recursion of A -> A α | β becomes A -> β A' and A' -> α A' | ε.
+	Productions A -> A are dropped, since they do not change the language.
+	Left recursion that goes through nonterminals deriving the empty string cannot always be removed this way,
+	in which case an error is returned.
+*/
+func (grammar *Grammar) EliminateLeftRecursion() (Grammar, error) {
+	nonterminals := grammar.Nonterminals()
+	productions := make(map[Symbol][]Production)
+	for _, production := range grammar.productions {
+		productions[production.Head] = append(productions[production.Head], production)
+	}
+
+	var order []Symbol
+	for _, nonterminal := range grammar.nonterminals {
+		// Substitutes the bodies of earlier nonterminals at the start of the bodies
+		for _, earlier := range order {
+			var substituted []Production
+			for _, production := range productions[nonterminal] {
+				if len(production.Body) == 0 || production.Body[0] != earlier {
+					substituted = append(substituted, production)
+					continue
+				}
+
+				for _, earlierProduction := range productions[earlier] {
+					body := append(append([]Symbol{}, earlierProduction.Body...), production.Body[1:]...)
+					substituted = sliceutil.AppendUnique(substituted, Production{nonterminal, body})
+				}
+			}
+
+			productions[nonterminal] = substituted
+		}
+
+		var recursive, others []Production
+		for _, production := range productions[nonterminal] {
+			switch {
+			case len(production.Body) == 1 && production.Body[0] == nonterminal:
+				continue
+			case len(production.Body) != 0 && production.Body[0] == nonterminal:
+				recursive = append(recursive, production)
+			default:
+				others = append(others, production)
+			}
+		}
+
+		order = append(order, nonterminal)
+
+		if len(recursive) == 0 {
+			productions[nonterminal] = others
+			continue
+		}
+
+		tail := freshNonterminal(nonterminal, nonterminals, grammar.terminals)
+		nonterminals = sliceutil.InsertAfter(nonterminals, nonterminal, tail)
+
+		productions[nonterminal] = nil
+		for _, production := range others {
+			productions[nonterminal] = append(productions[nonterminal], Production{nonterminal, append(append([]Symbol{}, production.Body...), tail)})
+		}
+
+		for _, production := range recursive {
+			productions[tail] = append(productions[tail], Production{tail, append(append([]Symbol{}, production.Body[1:]...), tail)})
+		}
+		productions[tail] = append(productions[tail], Production{tail, []Symbol{}})
+	}
+
+	transformed, err := NewGrammar(nonterminals, grammar.Terminals(), grammar.start, collect(nonterminals, productions))
+	if err != nil {
+		return Grammar{}, err
+	}
+
+	if nonterminal, ok := transformed.leftRecursive(); ok {
+		return Grammar{}, fmt.Errorf("the left recursion through '%v' cannot be removed because it goes through nonterminals deriving the empty string", nonterminal)
+	}
+
+	return transformed, nil
+}
+
+/*
+	Left-factors the grammar, so that no two productions of a nonterminal start with the same symbol.
+	The longest common prefix α of A -> α β1 | α β2 is kept in A -> α A', and the rest moves to A' -> β1 | β2.
+	Factoring is repeated until no productions share a first symbol.
+*/
+func (grammar *Grammar) LeftFactor() (Grammar, error) {
+	nonterminals := grammar.Nonterminals()
+	productions := make(map[Symbol][]Production)
+	for _, production := range grammar.productions {
+		productions[production.Head] = sliceutil.AppendUnique(productions[production.Head], production)
+	}
+
+	for i := 0; i < len(nonterminals); i++ {
+		nonterminal := nonterminals[i]
+
+		for isChanged := true; isChanged; {
+			isChanged = false
+
+			for _, production := range productions[nonterminal] {
+				if len(production.Body) == 0 {
+					continue
+				}
+
+				// Finds the productions sharing the first symbol and their longest common prefix
+				var group []Production
+				for _, other := range productions[nonterminal] {
+					if len(other.Body) != 0 && other.Body[0] == production.Body[0] {
+						group = append(group, other)
+					}
+				}
+
+				if len(group) == 1 {
+					continue
+				}
+
+				prefix := group[0].Body
+				for _, other := range group[1:] {
+					prefix = commonPrefix(prefix, other.Body)
+				}
+
+				tail := freshNonterminal(nonterminal, nonterminals, grammar.terminals)
+				nonterminals = sliceutil.InsertAfter(nonterminals, nonterminal, tail)
+
+				var factored []Production
+				for _, other := range productions[nonterminal] {
+					switch {
+					case other.String() == group[0].String():
+						factored = append(factored, Production{nonterminal, append(append([]Symbol{}, prefix...), tail)})
+					case len(other.Body) == 0 || other.Body[0] != production.Body[0]:
+						factored = append(factored, other)
+					}
+				}
+
+				for _, other := range group {
+					productions[tail] = sliceutil.AppendUnique(productions[tail], Production{tail, append([]Symbol{}, other.Body[len(prefix):]...)})
+				}
+
+				productions[nonterminal] = factored
+				isChanged = true
+				break
+			}
+		}
+	}
+
+	return NewGrammar(nonterminals, grammar.Terminals(), grammar.start, collect(nonterminals, productions))
+}
+
+/*
+	Removes the useless nonterminals and their productions.
+	Nonterminals that do not derive any string of terminals are removed first, and then the ones the start symbol does not reach.
+	If the start symbol does not derive any string of terminals, then an error is returned.
+*/
+func (grammar *Grammar) RemoveUseless() (Grammar, error) {
+	// Finds the nonterminals that derive a string of terminals
+	isGenerating := make(SymbolSet)
+	for isChanged := true; isChanged; {
+		isChanged = false
+
+		for _, production := range grammar.productions {
+			if isGenerating[production.Head] {
+				continue
+			}
+
+			isBodyGenerating := true
+			for _, symbol := range production.Body {
+				if grammar.IsNonterminal(symbol) && !isGenerating[symbol] {
+					isBodyGenerating = false
+					break
+				}
+			}
+
+			if isBodyGenerating {
+				isGenerating[production.Head] = true
+				isChanged = true
+			}
+		}
+	}
+
+	if !isGenerating[grammar.start] {
+		return Grammar{}, fmt.Errorf("the start symbol '%v' does not derive any string of terminals", grammar.start)
+	}
+
+	var generating []Production
+	for _, production := range grammar.productions {
+		isUseful := isGenerating[production.Head]
+		for _, symbol := range production.Body {
+			if grammar.IsNonterminal(symbol) && !isGenerating[symbol] {
+				isUseful = false
+			}
+		}
+
+		if isUseful {
+			generating = append(generating, production)
+		}
+	}
+
+	// Finds the nonterminals reachable from the start symbol through the remaining productions
+	isReachable := SymbolSet{grammar.start: true}
+	for isChanged := true; isChanged; {
+		isChanged = false
+
+		for _, production := range generating {
+			if !isReachable[production.Head] {
+				continue
+			}
+
+			for _, symbol := range production.Body {
+				if grammar.IsNonterminal(symbol) && !isReachable[symbol] {
+					isReachable[symbol] = true
+					isChanged = true
+				}
+			}
+		}
+	}
+
+	var nonterminals []Symbol
+	for _, nonterminal := range grammar.nonterminals {
+		if isReachable[nonterminal] {
+			nonterminals = append(nonterminals, nonterminal)
+		}
+	}
+
+	var productions []Production
+	for _, production := range generating {
+		if isReachable[production.Head] {
+			productions = append(productions, production)
+		}
+	}
+
+	return NewGrammar(nonterminals, grammar.Terminals(), grammar.start, productions)
+}
+
+/*
+	Finds a nonterminal that derives a string starting with itself.
+*/
+func (grammar *Grammar) leftRecursive() (Symbol, bool) {
+	nullable := grammar.Nullable()
+
+	// Links every nonterminal to the nonterminals that can start its derivations
+	starts := make(map[Symbol]SymbolSet)
+	for _, production := range grammar.productions {
+		if starts[production.Head] == nil {
+			starts[production.Head] = make(SymbolSet)
+		}
+
+		for _, symbol := range production.Body {
+			if grammar.IsNonterminal(symbol) {
+				starts[production.Head][symbol] = true
+			}
+
+			if !nullable[symbol] {
+				break
+			}
+		}
+	}
+
+	for _, nonterminal := range grammar.nonterminals {
+		visited := make(SymbolSet)
+		pending := starts[nonterminal].Sorted()
+
+		for len(pending) != 0 {
+			symbol := pending[0]
+			pending = pending[1:]
+
+			if symbol == nonterminal {
+				return nonterminal, true
+			}
+
+			if !visited[symbol] {
+				visited[symbol] = true
+				pending = append(pending, starts[symbol].Sorted()...)
+			}
+		}
+	}
+
+	return "", false
+}
+
+/*
+	Creates a nonterminal named after another one with primes added until the name is unused.
+*/
+func freshNonterminal(nonterminal Symbol, nonterminals []Symbol, terminals []Symbol) Symbol {
+	fresh := nonterminal + "'"
+	for sliceutil.Contains(nonterminals, fresh) || sliceutil.Contains(terminals, fresh) {
+		fresh += "'"
+	}
+
+	return fresh
+}
+
+/*
+	Lists the productions of every nonterminal in order.
+*/
+func collect(nonterminals []Symbol, productions map[Symbol][]Production) []Production {
+	var collected []Production
+	for _, nonterminal := range nonterminals {
+		collected = append(collected, productions[nonterminal]...)
+	}
+
+	return collected
+}
+
+/*
+	Gets the longest common prefix of two strings of symbols.
+*/
+func commonPrefix(a []Symbol, b []Symbol) []Symbol {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+
+	return a[:i]
+}
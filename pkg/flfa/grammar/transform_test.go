@@ -0,0 +1,167 @@
+package grammar
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/*
+	Creates the left recursive arithmetic grammar.
+*/
+func newLeftRecursiveGrammar(t *testing.T) Grammar {
+	t.Helper()
+
+	g, err := NewGrammar(
+		[]Symbol{"E", "T", "F"},
+		[]Symbol{"number", "+", "-", "*", "/", "(", ")"},
+		"E",
+		[]Production{
+			{"E", []Symbol{"E", "+", "T"}},
+			{"E", []Symbol{"E", "-", "T"}},
+			{"E", []Symbol{"T"}},
+			{"T", []Symbol{"T", "*", "F"}},
+			{"T", []Symbol{"T", "/", "F"}},
+			{"T", []Symbol{"F"}},
+			{"F", []Symbol{"(", "E", ")"}},
+			{"F", []Symbol{"number"}},
+		},
+	)
+	require.NoError(t, err)
+
+	return g
+}
+
+func TestEliminateLeftRecursion(t *testing.T) {
+	g := newLeftRecursiveGrammar(t)
+
+	transformed, err := g.EliminateLeftRecursion()
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []Symbol{"E", "E'", "T", "T'", "F"}, transformed.Nonterminals())
+	assert.Equal(t, ""+
+		"E -> T E'\n"+
+		"E' -> + T E'\n"+
+		"E' -> - T E'\n"+
+		"E' -> ε\n"+
+		"T -> F T'\n"+
+		"T' -> * F T'\n"+
+		"T' -> / F T'\n"+
+		"T' -> ε\n"+
+		"F -> ( E )\n"+
+		"F -> number\n", transformed.String())
+}
+
+func TestEliminateIndirectLeftRecursion(t *testing.T) {
+	// S -> A a -> S d a is left recursive through A
+	g, err := NewGrammar(
+		[]Symbol{"S", "A"},
+		[]Symbol{"a", "b", "c", "d"},
+		"S",
+		[]Production{
+			{"S", []Symbol{"A", "a"}},
+			{"S", []Symbol{"b"}},
+			{"A", []Symbol{"A", "c"}},
+			{"A", []Symbol{"S", "d"}},
+			{"A", []Symbol{"A"}},
+			{"A", []Symbol{"c"}},
+		},
+	)
+	assert.Equal(t, nil, err)
+
+	transformed, err := g.EliminateLeftRecursion()
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, ""+
+		"S -> A a\n"+
+		"S -> b\n"+
+		"A -> b d A'\n"+
+		"A -> c A'\n"+
+		"A' -> c A'\n"+
+		"A' -> a d A'\n"+
+		"A' -> ε\n", transformed.String())
+
+	_, ok := transformed.leftRecursive()
+	assert.False(t, ok)
+}
+
+func TestEliminateLeftRecursionErrors(t *testing.T) {
+	// S -> B S a is left recursive because B derives the empty string
+	g, err := NewGrammar(
+		[]Symbol{"S", "B"},
+		[]Symbol{"a", "b"},
+		"S",
+		[]Production{
+			{"S", []Symbol{"B", "S", "a"}},
+			{"S", []Symbol{"b"}},
+			{"B", []Symbol{}},
+		},
+	)
+	assert.Equal(t, nil, err)
+
+	_, err = g.EliminateLeftRecursion()
+
+	assert.Equal(t, fmt.Errorf("the left recursion through 'S' cannot be removed because it goes through nonterminals deriving the empty string"), err)
+}
+
+func TestLeftFactor(t *testing.T) {
+	g, err := NewGrammar(
+		[]Symbol{"S", "E"},
+		[]Symbol{"if", "then", "else", "end", "b", "a"},
+		"S",
+		[]Production{
+			{"S", []Symbol{"if", "E", "then", "S", "end"}},
+			{"S", []Symbol{"if", "E", "then", "S", "else", "S", "end"}},
+			{"S", []Symbol{"if", "E", "then", "S", "else", "if", "E", "then", "S", "end"}},
+			{"S", []Symbol{"a"}},
+			{"E", []Symbol{"b"}},
+			{"E", []Symbol{"b"}},
+		},
+	)
+	assert.Equal(t, nil, err)
+
+	transformed, err := g.LeftFactor()
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []Symbol{"S", "S'", "S''", "E"}, transformed.Nonterminals())
+	assert.Equal(t, ""+
+		"S -> if E then S S'\n"+
+		"S -> a\n"+
+		"S' -> end\n"+
+		"S' -> else S''\n"+
+		"S'' -> S end\n"+
+		"S'' -> if E then S end\n"+
+		"E -> b\n", transformed.String())
+}
+
+func TestRemoveUseless(t *testing.T) {
+	g, err := NewGrammar(
+		[]Symbol{"S", "A", "B", "C", "D"},
+		[]Symbol{"a", "b", "c"},
+		"S",
+		[]Production{
+			{"S", []Symbol{"A", "B"}},
+			{"S", []Symbol{"a"}},
+			{"A", []Symbol{"b"}},
+			{"B", []Symbol{"B", "c"}},
+			{"C", []Symbol{"c"}},
+			{"D", []Symbol{"C", "S"}},
+		},
+	)
+	assert.Equal(t, nil, err)
+
+	transformed, err := g.RemoveUseless()
+
+	// B derives no string of terminals, which leaves A unreachable, and C and D are unreachable
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []Symbol{"S"}, transformed.Nonterminals())
+	assert.Equal(t, "S -> a\n", transformed.String())
+
+	g, err = NewGrammar([]Symbol{"S"}, []Symbol{"a"}, "S", []Production{{"S", []Symbol{"a", "S"}}})
+	assert.Equal(t, nil, err)
+
+	_, err = g.RemoveUseless()
+	assert.Equal(t, fmt.Errorf("the start symbol 'S' does not derive any string of terminals"), err)
+}
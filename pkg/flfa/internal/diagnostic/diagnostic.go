@@ -0,0 +1,56 @@
+package diagnostic
+
+import (
+	"flfa/grammar"
+	"flfa/lexer"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+/*
+	Formats the message of a syntax error found at a token, such as
+
+		1:5: unexpected '*' while parsing 'E', expected '(' or 'id'
+
+	The context follows the token when not empty, and the expected terminals are left out when there are none.
+	A token with an empty lexeme and the end marker among the expected terminals are the end of the string.
+*/
+func Message(token lexer.Token, context string, expected []grammar.Symbol) string {
+	found := "end of the string"
+	if token.Lexeme != "" {
+		found = fmt.Sprintf("'%v'", token.Lexeme)
+	}
+
+	if context != "" {
+		found += " " + context
+	}
+
+	if len(expected) == 0 {
+		return fmt.Sprintf("%v: unexpected %v", token.Pos, found)
+	}
+
+	var terminals []string
+	for _, terminal := range expected {
+		if terminal == grammar.EndMarker {
+			terminals = append(terminals, "end of the string")
+		} else {
+			terminals = append(terminals, fmt.Sprintf("'%v'", terminal))
+		}
+	}
+
+	return fmt.Sprintf("%v: unexpected %v, expected %v", token.Pos, found, strings.Join(terminals, " or "))
+}
+
+/*
+	Sorts expected terminals with the end marker last, so that the end of the string is mentioned after the tokens.
+*/
+func SortExpected(expected []grammar.Symbol) {
+	sort.Slice(expected, func(i, j int) bool {
+		if expected[i] == grammar.EndMarker || expected[j] == grammar.EndMarker {
+			return expected[j] == grammar.EndMarker && expected[i] != grammar.EndMarker
+		}
+
+		return expected[i] < expected[j]
+	})
+}
@@ -0,0 +1,41 @@
+package diagnostic
+
+import (
+	"flfa/grammar"
+	"flfa/lexer"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessage(t *testing.T) {
+	star := lexer.Token{Kind: "*", Lexeme: "*", Pos: lexer.Position{Offset: 4, Line: 1, Column: 5}}
+	end := lexer.Token{Kind: lexer.Kind(grammar.EndMarker), Pos: lexer.Position{Offset: 6, Line: 1, Column: 7}}
+
+	var tests = []struct {
+		token    lexer.Token
+		context  string
+		expected []grammar.Symbol
+		want     string
+	}{
+		{star, "", []grammar.Symbol{"(", "id"}, "1:5: unexpected '*', expected '(' or 'id'"},
+		{star, "while parsing 'E'", []grammar.Symbol{"id"}, "1:5: unexpected '*' while parsing 'E', expected 'id'"},
+		{star, "", nil, "1:5: unexpected '*'"},
+		{end, "", []grammar.Symbol{")", grammar.EndMarker}, "1:7: unexpected end of the string, expected ')' or end of the string"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Message(tt.token, tt.context, tt.expected))
+	}
+}
+
+func TestSortExpected(t *testing.T) {
+	expected := []grammar.Symbol{grammar.EndMarker, "id", "(", "+"}
+	SortExpected(expected)
+	assert.Equal(t, []grammar.Symbol{"(", "+", "id", grammar.EndMarker}, expected)
+
+	// The end marker sorts last even though '$' sorts before the other symbols
+	expected = []grammar.Symbol{"a", grammar.EndMarker, "("}
+	SortExpected(expected)
+	assert.Equal(t, []grammar.Symbol{"(", "a", grammar.EndMarker}, expected)
+}
@@ -0,0 +1,15 @@
+package dot
+
+import (
+	"strings"
+)
+
+/*
+	Quotes a DOT identifier, escaping its backslashes and double quotes.
+*/
+func Quote(id string) string {
+	id = strings.ReplaceAll(id, "\\", "\\\\")
+	id = strings.ReplaceAll(id, "\"", "\\\"")
+
+	return "\"" + id + "\""
+}
@@ -0,0 +1,24 @@
+package dot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuote(t *testing.T) {
+	var tests = []struct {
+		id   string
+		want string
+	}{
+		{"q0", `"q0"`},
+		{"", `""`},
+		{`say "hi"`, `"say \"hi\""`},
+		{`a\b`, `"a\\b"`},
+		{`\"`, `"\\\""`},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Quote(tt.id))
+	}
+}
@@ -0,0 +1,46 @@
+package sliceutil
+
+import (
+	"fmt"
+)
+
+/*
+	Checks if a value is in a slice.
+*/
+func Contains[T comparable](values []T, value T) bool {
+	for _, possibleValue := range values {
+		if possibleValue == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+	Inserts a value right after another one, or at the end if the other one is not there.
+	The given values are left unchanged when the value is inserted before the end.
+*/
+func InsertAfter[T comparable](values []T, after T, value T) []T {
+	for i, possibleValue := range values {
+		if possibleValue == after {
+			return append(append(append([]T{}, values[:i+1]...), value), values[i+1:]...)
+		}
+	}
+
+	return append(values, value)
+}
+
+/*
+	Appends a value unless a value that prints the same is already there.
+	Values such as productions, which hold slices and cannot be compared, are told apart by how they print.
+*/
+func AppendUnique[T fmt.Stringer](values []T, value T) []T {
+	for _, possibleValue := range values {
+		if possibleValue.String() == value.String() {
+			return values
+		}
+	}
+
+	return append(values, value)
+}
@@ -0,0 +1,39 @@
+package sliceutil
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContains(t *testing.T) {
+	assert.True(t, Contains([]string{"a", "b"}, "b"))
+	assert.False(t, Contains([]string{"a", "b"}, "c"))
+	assert.False(t, Contains(nil, "a"))
+}
+
+func TestInsertAfter(t *testing.T) {
+	values := []string{"S", "A", "B"}
+
+	assert.Equal(t, []string{"S", "A", "A'", "B"}, InsertAfter(values, "A", "A'"))
+	assert.Equal(t, []string{"S", "A", "B", "B'"}, InsertAfter(values, "B", "B'"))
+	assert.Equal(t, []string{"S", "A", "B", "C"}, InsertAfter(values, "X", "C"))
+	assert.Equal(t, []string{"S", "A", "B"}, values[:3])
+}
+
+type number int
+
+func (n number) String() string {
+	return strconv.Itoa(int(n))
+}
+
+func TestAppendUnique(t *testing.T) {
+	values := []number{1}
+
+	values = AppendUnique(values, 1)
+	assert.Equal(t, []number{1}, values)
+
+	values = AppendUnique(values, 2)
+	assert.Equal(t, []number{1, 2}, values)
+}
@@ -0,0 +1,15 @@
+package statename
+
+import (
+	"strings"
+)
+
+/*
+	Escapes the characters that delimit the states joined into the name of a product or subset state,
+	so that different tuples or sets of states never get the same name.
+*/
+func Escape(name string) string {
+	return escaper.Replace(name)
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, ",", `\,`, "(", `\(`, ")", `\)`, "{", `\{`, "}", `\}`)
@@ -0,0 +1,25 @@
+package statename
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscape(t *testing.T) {
+	var tests = []struct {
+		name string
+		want string
+	}{
+		{"q0", "q0"},
+		{"", ""},
+		{"q0,q1", `q0\,q1`},
+		{"(p,q)", `\(p\,q\)`},
+		{"{q0}", `\{q0\}`},
+		{`a\b`, `a\\b`},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Escape(tt.name))
+	}
+}
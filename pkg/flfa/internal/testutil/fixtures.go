@@ -0,0 +1,57 @@
+package testutil
+
+import (
+	"flfa/grammar"
+	"flfa/lexer"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+/*
+	Creates the arithmetic grammar used by the arithmetic program, which is LL(1) and ends its strings with '$'.
+*/
+func ArithmeticGrammar(t *testing.T) grammar.Grammar {
+	t.Helper()
+
+	g, err := grammar.NewGrammar(
+		[]grammar.Symbol{"S", "t", "T", "r", "R", "v"},
+		[]grammar.Symbol{"d", "+", "-", "*", "/", "(", ")", "$"},
+		"S",
+		[]grammar.Production{
+			{Head: "S", Body: []grammar.Symbol{"t", "$"}},
+			{Head: "t", Body: []grammar.Symbol{"r", "T"}},
+			{Head: "T", Body: []grammar.Symbol{"+", "r", "T"}},
+			{Head: "T", Body: []grammar.Symbol{"-", "r", "T"}},
+			{Head: "T", Body: []grammar.Symbol{}},
+			{Head: "r", Body: []grammar.Symbol{"v", "R"}},
+			{Head: "R", Body: []grammar.Symbol{"*", "v", "R"}},
+			{Head: "R", Body: []grammar.Symbol{"/", "v", "R"}},
+			{Head: "R", Body: []grammar.Symbol{}},
+			{Head: "v", Body: []grammar.Symbol{"(", "t", ")"}},
+			{Head: "v", Body: []grammar.Symbol{"d"}},
+		},
+	)
+	require.NoError(t, err)
+
+	return g
+}
+
+/*
+	Creates a lexer for the expressions of identifiers, '+', '*' and parentheses, skipping whitespace.
+*/
+func ExpressionLexer(t *testing.T) lexer.Lexer {
+	t.Helper()
+
+	lex, err := lexer.NewLexer([]lexer.Rule{
+		{Kind: "id", Regex: "[a-z]+"},
+		{Kind: "+", Regex: "\\+"},
+		{Kind: "*", Regex: "\\*"},
+		{Kind: "(", Regex: "\\("},
+		{Kind: ")", Regex: "\\)"},
+		{Regex: "\\s+", Skip: true},
+	})
+	require.NoError(t, err)
+
+	return lex
+}
@@ -0,0 +1,23 @@
+package testutil
+
+/*
+	Lists every string over an alphabet of up to the given length, shortest first and in the order of the alphabet.
+*/
+func Strings[S ~rune | ~string](alphabet []S, length int) []string {
+	strs := []string{""}
+	last := []string{""}
+
+	for i := 0; i < length; i++ {
+		var next []string
+		for _, str := range last {
+			for _, symbol := range alphabet {
+				next = append(next, str+string(symbol))
+			}
+		}
+
+		strs = append(strs, next...)
+		last = next
+	}
+
+	return strs
+}
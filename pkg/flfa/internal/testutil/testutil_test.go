@@ -0,0 +1,14 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrings(t *testing.T) {
+	assert.Equal(t, []string{"", "a", "b", "aa", "ab", "ba", "bb"}, Strings([]rune{'a', 'b'}, 2))
+	assert.Equal(t, []string{"", "(", "ab", "((", "(ab", "ab(", "abab"}, Strings([]string{"(", "ab"}, 2))
+	assert.Equal(t, []string{""}, Strings([]rune{'a'}, 0))
+	assert.Equal(t, []string{""}, Strings([]rune(nil), 3))
+}
@@ -0,0 +1,223 @@
+package lexer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+/*
+	The kind of a token, which names the terminal it stands for in a grammar.
+*/
+type Kind string
+
+/*
+	A lexer rule, where tokens of the kind are matched by the regex.
+	Text matched by a skipped rule, such as whitespace or comments, does not emit tokens.
+*/
+type Rule struct {
+	Kind  Kind
+	Regex string
+	Skip  bool
+}
+
+/*
+	A position in the input, where the offset is in bytes and the line and column start at 1.
+	Columns count characters, not bytes.
+*/
+type Position struct {
+	Offset int
+	Line   int
+	Column int
+}
+
+type Token struct {
+	Kind   Kind
+	Lexeme string
+	Pos    Position
+}
+
+type compiledRule struct {
+	kind  Kind
+	regex *regexp.Regexp
+	skip  bool
+}
+
+/*
+	A lexer made of ordered rules.
+	At every position the rule with the longest match wins, and ties go to the rule that comes first.
+*/
+type Lexer struct {
+	rules []compiledRule
+}
+
+/*
+	Creates a lexer and validates its rules.
+	If a rule fails validation, then an empty lexer is returned.
+*/
+func NewLexer(rules []Rule) (Lexer, error) {
+	var compiledRules []compiledRule
+
+	for _, rule := range rules {
+		if rule.Kind == "" && !rule.Skip {
+			return Lexer{}, fmt.Errorf("the rule '%v' must have a kind unless it is skipped", rule.Regex)
+		}
+
+		regex, err := regexp.Compile("^(?:" + rule.Regex + ")")
+		if err != nil {
+			return Lexer{}, fmt.Errorf("the regex '%v' of the rule '%v' is invalid: %v", rule.Regex, rule.Kind, err)
+		}
+		regex.Longest()
+
+		compiledRules = append(compiledRules, compiledRule{rule.Kind, regex, rule.Skip})
+	}
+
+	return Lexer{compiledRules}, nil
+}
+
+/*
+	Gets the kinds of the rules that emit tokens, in rule order and without duplicates.
+*/
+func (lexer *Lexer) Kinds() []Kind {
+	var kinds []Kind
+
+	for _, rule := range lexer.rules {
+		if !rule.skip && !containsKind(kinds, rule.kind) {
+			kinds = append(kinds, rule.kind)
+		}
+	}
+
+	return kinds
+}
+
+/*
+	Splits a string into tokens.
+	Empty matches are ignored, and an error is returned at the first position where no rule matches.
+*/
+func (lexer *Lexer) Tokenize(str string) ([]Token, error) {
+	var tokens []Token
+
+	pos := Position{0, 1, 1}
+	for pos.Offset < len(str) {
+		length := 0
+		var match compiledRule
+
+		for _, rule := range lexer.rules {
+			if location := rule.regex.FindStringIndex(str[pos.Offset:]); location != nil && location[1] > length {
+				length = location[1]
+				match = rule
+			}
+		}
+
+		if length == 0 {
+			char, _ := utf8.DecodeRuneInString(str[pos.Offset:])
+			return nil, fmt.Errorf("the character '%v' at %v does not start any token", string(char), pos)
+		}
+
+		lexeme := str[pos.Offset : pos.Offset+length]
+		if !match.skip {
+			tokens = append(tokens, Token{match.kind, lexeme, pos})
+		}
+
+		pos = pos.advance(lexeme)
+	}
+
+	return tokens, nil
+}
+
+/*
+	Splits a string into one token per character, where the kind of every token is its character.
+*/
+func Runes(str string) []Token {
+	var tokens []Token
+
+	pos := Position{0, 1, 1}
+	for _, char := range str {
+		lexeme := string(char)
+		tokens = append(tokens, Token{Kind(lexeme), lexeme, pos})
+
+		pos = pos.advance(lexeme)
+	}
+
+	return tokens
+}
+
+/*
+	Gets the position right after the end of a string.
+*/
+func EndOf(str string) Position {
+	return Position{0, 1, 1}.advance(str)
+}
+
+/*
+	Renders the line of a string holding a token with carets under the token:
+
+		3 + * 4
+		    ^
+
+	A token with an empty lexeme, such as the end of the string, gets a single caret.
+*/
+func Snippet(str string, token Token) string {
+	offset := token.Pos.Offset
+	start := strings.LastIndex(str[:offset], "\n") + 1
+
+	end := strings.Index(str[offset:], "\n")
+	if end == -1 {
+		end = len(str)
+	} else {
+		end += offset
+	}
+
+	// Tabs are kept so that the carets line up with the line
+	var indent strings.Builder
+	for _, char := range str[start:offset] {
+		if char == '\t' {
+			indent.WriteRune('\t')
+		} else {
+			indent.WriteRune(' ')
+		}
+	}
+
+	width := utf8.RuneCountInString(token.Lexeme)
+	if width == 0 {
+		width = 1
+	}
+
+	return fmt.Sprintf("%v\n%v%v\n", str[start:end], indent.String(), strings.Repeat("^", width))
+}
+
+func (pos Position) String() string {
+	return fmt.Sprintf("%v:%v", pos.Line, pos.Column)
+}
+
+/*
+	Gets the position after reading the given text.
+*/
+func (pos Position) advance(text string) Position {
+	for _, char := range text {
+		if char == '\n' {
+			pos.Line++
+			pos.Column = 1
+		} else {
+			pos.Column++
+		}
+	}
+
+	pos.Offset += len(text)
+
+	return pos
+}
+
+/*
+	Checks if a kind is in a kind array.
+*/
+func containsKind(kinds []Kind, kind Kind) bool {
+	for _, possibleKind := range kinds {
+		if possibleKind == kind {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,118 @@
+package lexer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newArithmeticLexer(t *testing.T) Lexer {
+	t.Helper()
+
+	lexer, err := NewLexer([]Rule{
+		{Kind: "num", Regex: "\\d+(\\.\\d+)?"},
+		{Kind: "if", Regex: "if"},
+		{Kind: "id", Regex: "[a-z]+"},
+		{Kind: "op", Regex: "[-+*/]|\\*\\*"},
+		{Regex: "\\s+", Skip: true},
+	})
+	require.NoError(t, err)
+
+	return lexer
+}
+
+func TestTokenize(t *testing.T) {
+	lexer := newArithmeticLexer(t)
+
+	var tests = []struct {
+		str  string
+		want []Token
+	}{
+		{"", nil},
+		{
+			"12.5+x",
+			[]Token{
+				{"num", "12.5", Position{0, 1, 1}},
+				{"op", "+", Position{4, 1, 5}},
+				{"id", "x", Position{5, 1, 6}},
+			},
+		},
+		{
+			// The longest match wins over rule order, and ties go to the first rule
+			"if iffy ** 2",
+			[]Token{
+				{"if", "if", Position{0, 1, 1}},
+				{"id", "iffy", Position{3, 1, 4}},
+				{"op", "**", Position{8, 1, 9}},
+				{"num", "2", Position{11, 1, 12}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tokens, err := lexer.Tokenize(tt.str)
+
+		assert.Equal(t, nil, err, "string '%v'", tt.str)
+		assert.Equal(t, tt.want, tokens, "string '%v'", tt.str)
+	}
+
+	tokens, err := lexer.Tokenize("a\n  b\n   7 ?")
+
+	assert.Equal(t, fmt.Errorf("the character '?' at 3:6 does not start any token"), err)
+	assert.Nil(t, tokens)
+}
+
+func TestTokenizePositions(t *testing.T) {
+	lexer := newArithmeticLexer(t)
+
+	tokens, err := lexer.Tokenize("é\n  x")
+
+	assert.Equal(t, fmt.Errorf("the character 'é' at 1:1 does not start any token"), err)
+	assert.Nil(t, tokens)
+
+	tokens, err = lexer.Tokenize("a\n  b")
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []Token{
+		{"id", "a", Position{0, 1, 1}},
+		{"id", "b", Position{4, 2, 3}},
+	}, tokens)
+}
+
+func TestRunes(t *testing.T) {
+	assert.Equal(t, []Token{
+		{"é", "é", Position{0, 1, 1}},
+		{"\n", "\n", Position{2, 1, 2}},
+		{"b", "b", Position{3, 2, 1}},
+	}, Runes("é\nb"))
+}
+
+func TestNewLexer(t *testing.T) {
+	_, err := NewLexer([]Rule{{Kind: "a", Regex: "("}})
+	assert.Error(t, err)
+
+	_, err = NewLexer([]Rule{{Regex: "a"}})
+	assert.Equal(t, fmt.Errorf("the rule 'a' must have a kind unless it is skipped"), err)
+
+	lexer, err := NewLexer([]Rule{{Kind: "a", Regex: "a"}, {Regex: " ", Skip: true}, {Kind: "b", Regex: "b"}, {Kind: "a", Regex: "A"}})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []Kind{"a", "b"}, lexer.Kinds())
+}
+
+func TestSnippet(t *testing.T) {
+	var tests = []struct {
+		str   string
+		token Token
+		want  string
+	}{
+		{"3 + * 4", Token{"*", "*", Position{4, 1, 5}}, "3 + * 4\n    ^\n"},
+		{"begin\n\tprint while\nend", Token{"while", "while", Position{13, 2, 8}}, "\tprint while\n\t      ^^^^^\n"},
+		{"(1 + 2", Token{"$", "", Position{6, 1, 7}}, "(1 + 2\n      ^\n"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Snippet(tt.str, tt.token))
+	}
+}
@@ -0,0 +1,77 @@
+package ll1
+
+import (
+	"flfa/grammar"
+	"flfa/internal/diagnostic"
+	"flfa/lexer"
+	"flfa/parsetree"
+	"fmt"
+)
+
+/*
+	A syntax error found by the ll1, which callers can inspect with errors.As.
+	The token is the end token, with the end marker as its kind and an empty lexeme, when the string ended too early.
+	The nonterminal is the one on top of the stack when it had no push string for the token,
+	the head of the production holding the terminal on top of the stack when the terminal did not match,
+	and the start symbol when the string should have ended.
+	The expected terminals come from the nonterminal's row of the table, and hold the end marker when the string should have ended.
+*/
+type SyntaxError struct {
+	Input       string
+	Token       lexer.Token
+	Nonterminal grammar.Symbol
+	Expected    []grammar.Symbol
+}
+
+func (err *SyntaxError) Error() string {
+	context := ""
+	if err.Nonterminal != "" {
+		context = fmt.Sprintf("while parsing '%v'", err.Nonterminal)
+	}
+
+	return diagnostic.Message(err.Token, context, err.Expected)
+}
+
+/*
+	Renders the line of the input holding the error with carets under the offending token.
+*/
+func (err *SyntaxError) Snippet() string {
+	return lexer.Snippet(err.Input, err.Token)
+}
+
+/*
+	Creates the syntax error of reading a token with a node on top of the stack, or with an empty stack if the node is nil.
+	The heads map every node of a push string to the nonterminal it was expanded from.
+*/
+func (ll1 *ll1) syntaxError(str string, token lexer.Token, top *parsetree.Node, heads map[*parsetree.Node]grammar.Symbol) *SyntaxError {
+	if top == nil {
+		return &SyntaxError{str, token, ll1.startSymbol, []grammar.Symbol{endMarker}}
+	}
+
+	if top.Terminal {
+		return &SyntaxError{str, token, heads[top], []grammar.Symbol{grammar.Symbol(top.Symbol)}}
+	}
+
+	return &SyntaxError{str, token, grammar.Symbol(top.Symbol), ll1.expected(grammar.Symbol(top.Symbol))}
+}
+
+/*
+	Gets the terminals in a nonterminal's row of the table, sorted with the end marker last.
+*/
+func (ll1 *ll1) expected(nonterminal grammar.Symbol) []grammar.Symbol {
+	var expected []grammar.Symbol
+	for terminal := range ll1.table[nonterminal] {
+		expected = append(expected, terminal)
+	}
+
+	diagnostic.SortExpected(expected)
+
+	return expected
+}
+
+/*
+	Gets the end token of a string.
+*/
+func endToken(str string) lexer.Token {
+	return lexer.Token{Kind: lexer.Kind(endMarker), Pos: lexer.EndOf(str)}
+}
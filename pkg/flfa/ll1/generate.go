@@ -0,0 +1,180 @@
+package ll1
+
+import (
+	"flfa/grammar"
+	"flfa/lexer"
+	"fmt"
+	"strings"
+)
+
+/*
+	A cell of an LL(1) table that more than one production is predicted for.
+*/
+type Conflict struct {
+	Nonterminal grammar.Symbol
+	Terminal    grammar.Symbol
+	Productions []grammar.Production
+}
+
+/*
+	The error returned for a grammar that is not LL(1), holding every conflicting cell.
+*/
+type ConflictError struct {
+	Conflicts []Conflict
+}
+
+func (err *ConflictError) Error() string {
+	var conflicts []string
+	for _, conflict := range err.Conflicts {
+		conflicts = append(conflicts, conflict.String())
+	}
+
+	return fmt.Sprintf("the grammar is not LL(1): %v", strings.Join(conflicts, "; "))
+}
+
+func (conflict Conflict) String() string {
+	var productions []string
+	for _, production := range conflict.Productions {
+		productions = append(productions, fmt.Sprintf("'%v'", production))
+	}
+
+	return fmt.Sprintf("the nonterminal '%v' with the lookahead '%v' predicts %v", conflict.Nonterminal, conflict.Terminal, strings.Join(productions, " and "))
+}
+
+/*
+	Creates a ll1 from a grammar, generating its LL(1) table.
+	The ll1 reads the string character by character, so every terminal of the grammar must be a single character.
+*/
+func NewLL1FromGrammar(g grammar.Grammar, regexesReplaces []RegexReplace) (ll1, error) {
+	table, err := GenerateTable(g)
+	if err != nil {
+		return initializeLL1(), err
+	}
+
+	for _, terminal := range g.Terminals() {
+		if len([]rune(terminal)) != 1 {
+			return initializeLL1(), fmt.Errorf("the symbol '%v' must be a single character", terminal)
+		}
+	}
+
+	compiledRegexesReplaces, err := compileRegexesReplaces(regexesReplaces)
+	if err != nil {
+		return initializeLL1(), err
+	}
+
+	ll1 := ll1{g.Nonterminals(), g.Terminals(), g.Start(), table, compiledRegexesReplaces, nil}
+
+	err = ll1.validate()
+	if err != nil {
+		return initializeLL1(), err
+	}
+
+	return ll1, nil
+}
+
+/*
+	Creates a ll1 that reads the tokens of a lexer from a grammar, generating its LL(1) table.
+	Every token kind of the lexer must be a terminal of the grammar.
+*/
+func NewLL1FromGrammarWithLexer(g grammar.Grammar, lexer lexer.Lexer) (ll1, error) {
+	table, err := GenerateTable(g)
+	if err != nil {
+		return initializeLL1(), err
+	}
+
+	return NewLL1WithLexer(g.Nonterminals(), g.Terminals(), g.Start(), table, lexer)
+}
+
+/*
+	Generates the LL(1) table of a grammar from its FIRST and FOLLOW sets.
+	A production is predicted for every terminal in the FIRST set of its body,
+	and for every terminal in the FOLLOW set of its head if its body derives the empty string.
+	If the grammar is not LL(1), then a *ConflictError holding every conflicting cell is returned.
+*/
+func GenerateTable(g grammar.Grammar) (Table, error) {
+	predictions := predict(g)
+
+	var conflicts []Conflict
+	table := make(Table)
+
+	for _, nonterminal := range g.Nonterminals() {
+		for _, terminal := range lookaheads(g) {
+			productions := predictions[nonterminal][terminal]
+
+			if len(productions) > 1 {
+				conflicts = append(conflicts, Conflict{nonterminal, terminal, productions})
+				continue
+			}
+
+			if len(productions) == 0 {
+				continue
+			}
+
+			if _, ok := table[nonterminal]; !ok {
+				table[nonterminal] = make(map[grammar.Symbol][]grammar.Symbol)
+			}
+
+			table[nonterminal][terminal] = append([]grammar.Symbol{}, productions[0].Body...)
+		}
+	}
+
+	if len(conflicts) != 0 {
+		return nil, &ConflictError{conflicts}
+	}
+
+	return table, nil
+}
+
+/*
+	Finds the productions predicted for every nonterminal and lookahead terminal.
+*/
+func predict(g grammar.Grammar) map[grammar.Symbol]map[grammar.Symbol][]grammar.Production {
+	nullable := g.Nullable()
+	first := g.First()
+	follow := g.Follow()
+
+	predictions := make(map[grammar.Symbol]map[grammar.Symbol][]grammar.Production)
+	for _, nonterminal := range g.Nonterminals() {
+		predictions[nonterminal] = make(map[grammar.Symbol][]grammar.Production)
+	}
+
+	for _, production := range g.Productions() {
+		lookaheads := make(grammar.SymbolSet)
+		isNullable := true
+
+		for _, symbol := range production.Body {
+			for terminal := range first[symbol] {
+				lookaheads[terminal] = true
+			}
+
+			if !nullable[symbol] {
+				isNullable = false
+				break
+			}
+		}
+
+		if isNullable {
+			for terminal := range follow[production.Head] {
+				lookaheads[terminal] = true
+			}
+		}
+
+		for terminal := range lookaheads {
+			predictions[production.Head][terminal] = append(predictions[production.Head][terminal], production)
+		}
+	}
+
+	return predictions
+}
+
+/*
+	Lists the grammar's terminals followed by the end marker if it is not a terminal.
+*/
+func lookaheads(g grammar.Grammar) []grammar.Symbol {
+	terminals := g.Terminals()
+	if !g.IsTerminal(grammar.EndMarker) {
+		terminals = append(terminals, grammar.EndMarker)
+	}
+
+	return terminals
+}
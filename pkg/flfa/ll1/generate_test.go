@@ -0,0 +1,227 @@
+package ll1
+
+import (
+	"errors"
+	"flfa/grammar"
+	"flfa/internal/testutil"
+	"flfa/lexer"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateTable(t *testing.T) {
+	g := testutil.ArithmeticGrammar(t)
+
+	table, err := GenerateTable(g)
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, Table{
+		"S": {
+			"(": {"t", "$"},
+			"d": {"t", "$"},
+		},
+		"t": {
+			"(": {"r", "T"},
+			"d": {"r", "T"},
+		},
+		"T": {
+			"+": {"+", "r", "T"},
+			"-": {"-", "r", "T"},
+			")": {},
+			"$": {},
+		},
+		"r": {
+			"(": {"v", "R"},
+			"d": {"v", "R"},
+		},
+		"R": {
+			"+": {},
+			"-": {},
+			"*": {"*", "v", "R"},
+			"/": {"/", "v", "R"},
+			")": {},
+			"$": {},
+		},
+		"v": {
+			"(": {"(", "t", ")"},
+			"d": {"d"},
+		},
+	}, table)
+}
+
+func TestGenerateTableConflicts(t *testing.T) {
+	// Left recursion and a common prefix both make the grammar not LL(1)
+	g, err := grammar.NewGrammar(
+		[]grammar.Symbol{"E", "F"},
+		[]grammar.Symbol{"+", "a", "b"},
+		"E",
+		[]grammar.Production{
+			{Head: "E", Body: []grammar.Symbol{"E", "+", "F"}},
+			{Head: "E", Body: []grammar.Symbol{"F"}},
+			{Head: "F", Body: []grammar.Symbol{"a"}},
+			{Head: "F", Body: []grammar.Symbol{"a", "b"}},
+		},
+	)
+	assert.Equal(t, nil, err)
+
+	_, err = GenerateTable(g)
+
+	var conflictError *ConflictError
+	assert.True(t, errors.As(err, &conflictError))
+	assert.Equal(t, []Conflict{
+		{"E", "a", g.Productions()[:2]},
+		{"F", "a", g.Productions()[2:]},
+	}, conflictError.Conflicts)
+	assert.Equal(t, "the grammar is not LL(1): the nonterminal 'E' with the lookahead 'a' predicts 'E -> E + F' and 'E -> F'; the nonterminal 'F' with the lookahead 'a' predicts 'F -> a' and 'F -> a b'", err.Error())
+}
+
+func TestNewLL1FromGrammar(t *testing.T) {
+	// Balanced parentheses, which relies on the implicit end marker
+	g, err := grammar.NewGrammar(
+		[]grammar.Symbol{"S"},
+		[]grammar.Symbol{"(", ")"},
+		"S",
+		[]grammar.Production{
+			{Head: "S", Body: []grammar.Symbol{"(", "S", ")", "S"}},
+			{Head: "S", Body: []grammar.Symbol{}},
+		},
+	)
+	assert.Equal(t, nil, err)
+
+	ll1, err := NewLL1FromGrammar(g, nil)
+	assert.Equal(t, nil, err)
+
+	var tests = []struct {
+		str  string
+		want string
+	}{
+		{"", ""},
+		{"()", ""},
+		{"(()())()", ""},
+		{"(()", "1:4: unexpected end of the string while parsing 'S', expected ')'"},
+		{"())", "1:3: unexpected ')' while parsing 'S', expected end of the string"},
+	}
+
+	for _, tt := range tests {
+		assertError(t, tt.want, ll1.Solve(tt.str), "string '%v'", tt.str)
+	}
+
+	g, err = grammar.NewGrammar([]grammar.Symbol{"S"}, []grammar.Symbol{"ab"}, "S", []grammar.Production{{Head: "S", Body: []grammar.Symbol{"ab"}}})
+	assert.Equal(t, nil, err)
+
+	_, err = NewLL1FromGrammar(g, nil)
+	assert.Equal(t, fmt.Errorf("the symbol 'ab' must be a single character"), err)
+}
+
+func TestNewLL1FromGrammarWithLexer(t *testing.T) {
+	// Keywords are terminals of more than one character
+	g, err := grammar.NewGrammar(
+		[]grammar.Symbol{"Stmt", "Stmts"},
+		[]grammar.Symbol{"while", "do", "begin", "end", "print", "id"},
+		"Stmt",
+		[]grammar.Production{
+			{Head: "Stmt", Body: []grammar.Symbol{"while", "id", "do", "Stmt"}},
+			{Head: "Stmt", Body: []grammar.Symbol{"begin", "Stmts", "end"}},
+			{Head: "Stmt", Body: []grammar.Symbol{"print", "id"}},
+			{Head: "Stmts", Body: []grammar.Symbol{"Stmt", "Stmts"}},
+			{Head: "Stmts", Body: []grammar.Symbol{}},
+		},
+	)
+	assert.Equal(t, nil, err)
+
+	lex, err := lexer.NewLexer([]lexer.Rule{
+		{Kind: "while", Regex: "while"},
+		{Kind: "do", Regex: "do"},
+		{Kind: "begin", Regex: "begin"},
+		{Kind: "end", Regex: "end"},
+		{Kind: "print", Regex: "print"},
+		{Kind: "id", Regex: "[a-z]+"},
+		{Regex: "\\s+", Skip: true},
+	})
+	assert.Equal(t, nil, err)
+
+	ll1, err := NewLL1FromGrammarWithLexer(g, lex)
+	assert.Equal(t, nil, err)
+
+	var tests = []struct {
+		str  string
+		want string
+	}{
+		{"print x", ""},
+		{"while ready do begin print x print y end", ""},
+		{"begin end", ""},
+		{"while done do print ending", ""},
+		{"print do", "1:7: unexpected 'do' while parsing 'Stmt', expected 'id'"},
+		{"while x do", "1:11: unexpected end of the string while parsing 'Stmt', expected 'begin' or 'print' or 'while'"},
+		{"begin print x", "1:14: unexpected end of the string while parsing 'Stmts', expected 'begin' or 'end' or 'print' or 'while'"},
+		{"begin print x end end", "1:19: unexpected 'end' while parsing 'Stmt', expected end of the string"},
+	}
+
+	for _, tt := range tests {
+		assertError(t, tt.want, ll1.Solve(tt.str), "string '%v'", tt.str)
+	}
+}
+
+/*
+	Asserts that an error has the given message, or that there is no error if the message is empty.
+*/
+func assertError(t *testing.T, want string, err error, msgAndArgs ...interface{}) {
+	if want == "" {
+		assert.Nil(t, err, msgAndArgs...)
+	} else {
+		assert.EqualError(t, err, want, msgAndArgs...)
+	}
+}
+
+func TestGenerateTableTransformedGrammar(t *testing.T) {
+	// The left recursive grammar of statements and arithmetic is not LL(1) until it is transformed
+	g, err := grammar.NewGrammar(
+		[]grammar.Symbol{"Stmt", "Expr", "Term", "Unused"},
+		[]grammar.Symbol{"print", "return", "number", "+", "*", ";"},
+		"Stmt",
+		[]grammar.Production{
+			{Head: "Stmt", Body: []grammar.Symbol{"print", "Expr", ";"}},
+			{Head: "Stmt", Body: []grammar.Symbol{"print", ";"}},
+			{Head: "Stmt", Body: []grammar.Symbol{"return", ";"}},
+			{Head: "Expr", Body: []grammar.Symbol{"Expr", "+", "Term"}},
+			{Head: "Expr", Body: []grammar.Symbol{"Term"}},
+			{Head: "Term", Body: []grammar.Symbol{"Term", "*", "number"}},
+			{Head: "Term", Body: []grammar.Symbol{"number"}},
+			{Head: "Unused", Body: []grammar.Symbol{"Unused"}},
+		},
+	)
+	assert.Equal(t, nil, err)
+
+	_, err = GenerateTable(g)
+	assert.Error(t, err)
+
+	g, err = g.RemoveUseless()
+	assert.Equal(t, nil, err)
+
+	g, err = g.EliminateLeftRecursion()
+	assert.Equal(t, nil, err)
+
+	g, err = g.LeftFactor()
+	assert.Equal(t, nil, err)
+
+	lex, err := lexer.NewLexer([]lexer.Rule{
+		{Kind: "print", Regex: "print"},
+		{Kind: "return", Regex: "return"},
+		{Kind: "number", Regex: "\\d+"},
+		{Kind: "+", Regex: "\\+"},
+		{Kind: "*", Regex: "\\*"},
+		{Kind: ";", Regex: ";"},
+		{Regex: "\\s+", Skip: true},
+	})
+	assert.Equal(t, nil, err)
+
+	ll1, err := NewLL1FromGrammarWithLexer(g, lex)
+	assert.Equal(t, nil, err)
+
+	assert.Nil(t, ll1.Solve("print 1 + 2 * 3 + 4;"))
+	assert.Nil(t, ll1.Solve("print;"))
+	assert.Nil(t, ll1.Solve("return;"))
+	assert.Error(t, ll1.Solve("print 1 +;"))
+}
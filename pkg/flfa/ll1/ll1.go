@@ -1,12 +1,28 @@
 package ll1
 
 import (
+	"flfa/grammar"
+	"flfa/lexer"
+	"flfa/parsetree"
 	"fmt"
 	"regexp"
 )
 
+/*
+	The LL(1) table of single character symbols, kept for the tables written before symbols were strings.
+*/
 type LL1Table map[rune]map[rune][]rune
 
+/*
+	The LL(1) table mapping every nonterminal and lookahead terminal to the symbols pushed in place of the nonterminal.
+*/
+type Table map[grammar.Symbol]map[grammar.Symbol][]grammar.Symbol
+
+/*
+	The lookahead used once the whole string has been read.
+*/
+const endMarker = grammar.EndMarker
+
 type RegexReplace struct {
 	Regex       string
 	Replacement string
@@ -17,12 +33,18 @@ type compiledRegexReplace struct {
 	replacement string
 }
 
+/*
+	The exported name of a ll1, so that other packages can hold parsers.
+*/
+type LL1 = ll1
+
 type ll1 struct {
-	nonterminalAlphabet []rune
-	terminalAlphabet    []rune
-	startSymbol         rune
-	ll1Table            LL1Table
+	nonterminalAlphabet []grammar.Symbol
+	terminalAlphabet    []grammar.Symbol
+	startSymbol         grammar.Symbol
+	table               Table
 	regexesReplaces     []compiledRegexReplace
+	lexer               *lexer.Lexer
 }
 
 /*
@@ -30,11 +52,12 @@ type ll1 struct {
 */
 func initializeLL1() ll1 {
 	return ll1{
-		[]rune([]rune(nil)),
-		[]rune([]rune(nil)),
-		rune(' '),
-		LL1Table(LL1Table(nil)),
+		[]grammar.Symbol([]grammar.Symbol(nil)),
+		[]grammar.Symbol([]grammar.Symbol(nil)),
+		grammar.Symbol(""),
+		Table(Table(nil)),
 		[]compiledRegexReplace([]compiledRegexReplace(nil)),
+		(*lexer.Lexer)(nil),
 	}
 }
 
@@ -43,19 +66,28 @@ func initializeLL1() ll1 {
   If the ll1 fails validation, then an empty ll1 is returned.
 */
 func NewLL1(nonterminalAlphabet []rune, terminalAlphabet []rune, startSymbol rune, ll1Table LL1Table, regexesReplaces []RegexReplace) (ll1, error) {
-	var compiledRegexesReplaces []compiledRegexReplace
+	compiledRegexesReplaces, err := compileRegexesReplaces(regexesReplaces)
+	if err != nil {
+		return initializeLL1(), err
+	}
 
-	// Compiles the given regexes
-	for _, regexReplace := range regexesReplaces {
-		compiledRegex, err := regexp.Compile(regexReplace.Regex)
-		if err != nil {
-			return initializeLL1(), err
-		}
+	ll1 := ll1{toSymbols(nonterminalAlphabet), toSymbols(terminalAlphabet), grammar.Symbol(startSymbol), ll1Table.toTable(), compiledRegexesReplaces, nil}
 
-		compiledRegexesReplaces = append(compiledRegexesReplaces, compiledRegexReplace{compiledRegex, regexReplace.Replacement})
+	err = ll1.validate()
+	if err != nil {
+		return initializeLL1(), err
 	}
 
-	ll1 := ll1{nonterminalAlphabet, terminalAlphabet, startSymbol, ll1Table, compiledRegexesReplaces}
+	return ll1, nil
+}
+
+/*
+	Creates a ll1 that reads the tokens of a lexer instead of characters, and validates it.
+	Symbols may be any strings, and every token kind of the lexer must be in the terminal alphabet.
+	If the ll1 fails validation, then an empty ll1 is returned.
+*/
+func NewLL1WithLexer(nonterminalAlphabet []grammar.Symbol, terminalAlphabet []grammar.Symbol, startSymbol grammar.Symbol, table Table, lexer lexer.Lexer) (ll1, error) {
+	ll1 := ll1{nonterminalAlphabet, terminalAlphabet, startSymbol, table, nil, &lexer}
 
 	err := ll1.validate()
 	if err != nil {
@@ -69,42 +101,134 @@ func NewLL1(nonterminalAlphabet []rune, terminalAlphabet []rune, startSymbol run
   Validates and solves a ll1 given a string.
 */
 func (ll1 *ll1) Solve(str string) error {
+	_, err := ll1.Parse(str)
+
+	return err
+}
+
+/*
+	Validates and solves a ll1 given a string, building the parse tree of the string.
+	Every nonterminal node has a child for each symbol of the production it was expanded with,
+	and every terminal leaf holds the text it matched and its span in the string.
+	Without a lexer, the string is read one character at a time after the regex replacements, and spans refer to the replaced string.
+*/
+func (ll1 *ll1) Parse(str string) (*parsetree.Node, error) {
 	err := ll1.validate()
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	tokens, str, err := ll1.tokenize(str)
+	if err != nil {
+		return nil, err
+	}
+
+	return ll1.parseTokens(str, tokens)
+}
+
+/*
+	Splits a string into tokens with the ll1's lexer,
+	or into characters after the regex replacements if the ll1 has no lexer.
+	The string the token positions refer to is returned with the tokens.
+*/
+func (ll1 *ll1) tokenize(str string) ([]lexer.Token, string, error) {
+	if ll1.lexer != nil {
+		tokens, err := ll1.lexer.Tokenize(str)
+		return tokens, str, err
 	}
 
 	for _, regexReplace := range ll1.regexesReplaces {
 		str = regexReplace.regex.ReplaceAllString(str, regexReplace.replacement)
 	}
 
-	stack := []rune{ll1.startSymbol}
+	return lexer.Runes(str), str, nil
+}
 
-	for i, char := range str {
-		err := ll1.validateChar(char)
-		if err != nil {
-			return err
+/*
+	Runs the LL(1) driver on the tokens of a string.
+	Every token is read as the terminal named by its kind, and the first syntax error stops the parse.
+*/
+func (ll1 *ll1) parseTokens(str string, tokens []lexer.Token) (*parsetree.Node, error) {
+	root := &parsetree.Node{Symbol: string(ll1.startSymbol)}
+	stack := []*parsetree.Node{root}
+	heads := make(map[*parsetree.Node]grammar.Symbol)
+
+	for _, token := range tokens {
+		terminal := grammar.Symbol(token.Kind)
+
+		if len(stack) == 0 {
+			return nil, ll1.syntaxError(str, token, nil, heads)
 		}
 
-		for len(stack) != 0 && char != stack[0] {
-			if pushString, ok := ll1.ll1Table[stack[0]][char]; ok {
-				stack = stack[1:]
-				stack = append(pushString, stack...)
-			} else {
-				return fmt.Errorf("there is push string while reading '%v' and popping '%v' at the position '%v ~error~ %v'", string(char), stack[0], str[:i], str[i:])
+		if ll1.validateTerminal(terminal) != nil {
+			return nil, ll1.syntaxError(str, token, stack[0], heads)
+		}
+
+		for !stack[0].Terminal {
+			pushString, ok := ll1.table[grammar.Symbol(stack[0].Symbol)][terminal]
+			if !ok {
+				return nil, ll1.syntaxError(str, token, stack[0], heads)
+			}
+
+			stack = append(ll1.expand(stack[0], pushString, token.Pos.Offset, heads), stack[1:]...)
+
+			if len(stack) == 0 {
+				return nil, ll1.syntaxError(str, token, nil, heads)
 			}
 		}
 
-		if len(stack) != 0 && char == stack[0] {
-			stack = stack[1:]
+		if stack[0].Symbol != string(terminal) {
+			return nil, ll1.syntaxError(str, token, stack[0], heads)
 		}
 
-		if len(stack) == 0 && i+1 != len([]rune(str)) {
-			return fmt.Errorf("there is nothing to pop while reading '%v' at the position '%v ~error~ %v'", string(char), str[:i+1], str[i+1:])
+		stack[0].Lexeme = token.Lexeme
+		stack[0].Span = parsetree.Span{Start: token.Pos.Offset, End: token.Pos.Offset + len(token.Lexeme)}
+		stack = stack[1:]
+	}
+
+	// The remaining nonterminals are expanded as if the end marker was read
+	for len(stack) != 0 {
+		pushString, ok := ll1.table[grammar.Symbol(stack[0].Symbol)][endMarker]
+		if !ok || stack[0].Terminal {
+			return nil, ll1.syntaxError(str, endToken(str), stack[0], heads)
 		}
+
+		stack = append(ll1.expand(stack[0], pushString, len(str), heads), stack[1:]...)
 	}
 
-	return nil
+	root.FitSpans()
+
+	return root, nil
+}
+
+/*
+	Expands a nonterminal node with a push string, returning its new children in order.
+	The node gets an empty span at the given position, which is kept if the push string is empty,
+	and the node's nonterminal is recorded in the heads as the head of every child.
+*/
+func (ll1 *ll1) expand(node *parsetree.Node, pushString []grammar.Symbol, position int, heads map[*parsetree.Node]grammar.Symbol) []*parsetree.Node {
+	node.Span = parsetree.Span{Start: position, End: position}
+
+	for _, symbol := range pushString {
+		child := &parsetree.Node{Symbol: string(symbol), Terminal: !ll1.isNonterminal(symbol)}
+		node.Children = append(node.Children, child)
+		heads[child] = grammar.Symbol(node.Symbol)
+	}
+
+	return append([]*parsetree.Node(nil), node.Children...)
+}
+
+/*
+	Checks if a symbol is in the ll1's nonterminal alphabet.
+*/
+func (ll1 *ll1) isNonterminal(symbol grammar.Symbol) bool {
+	for _, nonterminal := range ll1.nonterminalAlphabet {
+		if symbol == nonterminal {
+			return true
+		}
+	}
+
+	return false
 }
 
 /*
@@ -116,26 +240,34 @@ func (ll1 *ll1) validate() error {
 		return err
 	}
 
+	if ll1.lexer != nil {
+		for _, kind := range ll1.lexer.Kinds() {
+			if ll1.validateTerminal(grammar.Symbol(kind)) != nil {
+				return fmt.Errorf("the token kind '%v' is not in the terminal alphabet", kind)
+			}
+		}
+	}
+
 	return nil
 }
 
 /*
-	Validates a given character against the ll1's terminal alphabet.
+	Validates a given symbol against the ll1's terminal alphabet.
 */
-func (ll1 *ll1) validateChar(char rune) error {
-	for _, terminalChar := range ll1.terminalAlphabet {
-		if char == terminalChar {
+func (ll1 *ll1) validateTerminal(symbol grammar.Symbol) error {
+	for _, terminal := range ll1.terminalAlphabet {
+		if symbol == terminal {
 			return nil
 		}
 	}
 
-	return fmt.Errorf("character '%v' is not a terminal character", char)
+	return fmt.Errorf("the symbol '%v' is not a terminal symbol", symbol)
 }
 
 /*
 	Validates the ll1's starting symbol against the ll1's nonterminal alphabet.
 */
-func validateStartSymbol(startSymbol rune, nonterminalAlphabet []rune) error {
+func validateStartSymbol(startSymbol grammar.Symbol, nonterminalAlphabet []grammar.Symbol) error {
 	for _, nonterminal := range nonterminalAlphabet {
 		if startSymbol == nonterminal {
 			return nil
@@ -143,3 +275,54 @@ func validateStartSymbol(startSymbol rune, nonterminalAlphabet []rune) error {
 	}
 	return fmt.Errorf("the starting symbol '%v' is not in the nonterminal alphabet", startSymbol)
 }
+
+/*
+	Compiles the given regexes.
+*/
+func compileRegexesReplaces(regexesReplaces []RegexReplace) ([]compiledRegexReplace, error) {
+	var compiledRegexesReplaces []compiledRegexReplace
+
+	for _, regexReplace := range regexesReplaces {
+		compiledRegex, err := regexp.Compile(regexReplace.Regex)
+		if err != nil {
+			return nil, err
+		}
+
+		compiledRegexesReplaces = append(compiledRegexesReplaces, compiledRegexReplace{compiledRegex, regexReplace.Replacement})
+	}
+
+	return compiledRegexesReplaces, nil
+}
+
+/*
+	Converts characters into symbols.
+*/
+func toSymbols(chars []rune) []grammar.Symbol {
+	symbols := make([]grammar.Symbol, 0, len(chars))
+
+	for _, char := range chars {
+		symbols = append(symbols, grammar.Symbol(char))
+	}
+
+	return symbols
+}
+
+/*
+	Converts a rune-based LL(1) table into a table of symbols.
+*/
+func (ll1Table LL1Table) toTable() Table {
+	if ll1Table == nil {
+		return nil
+	}
+
+	table := make(Table, len(ll1Table))
+	for nonterminal, row := range ll1Table {
+		table[grammar.Symbol(nonterminal)] = make(map[grammar.Symbol][]grammar.Symbol, len(row))
+
+		for terminal, pushString := range row {
+			table[grammar.Symbol(nonterminal)][grammar.Symbol(terminal)] = toSymbols(pushString)
+		}
+	}
+
+	return table
+}
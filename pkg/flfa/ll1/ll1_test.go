@@ -0,0 +1,174 @@
+package ll1
+
+import (
+	"errors"
+	"flfa/grammar"
+	"flfa/lexer"
+	"flfa/parsetree"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	ll1, err := NewLL1(
+		[]rune{'S'},
+		[]rune{'(', ')'},
+		'S',
+		LL1Table{
+			'S': {
+				'(': {'(', 'S', ')', 'S'},
+				')': {},
+				'$': {},
+			},
+		},
+		nil,
+	)
+	assert.Equal(t, nil, err)
+
+	tree, err := ll1.Parse("()")
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &parsetree.Node{
+		Symbol: "S",
+		Span:   parsetree.Span{Start: 0, End: 2},
+		Children: []*parsetree.Node{
+			{Symbol: "(", Terminal: true, Lexeme: "(", Span: parsetree.Span{Start: 0, End: 1}},
+			{Symbol: "S", Span: parsetree.Span{Start: 1, End: 1}},
+			{Symbol: ")", Terminal: true, Lexeme: ")", Span: parsetree.Span{Start: 1, End: 2}},
+			{Symbol: "S", Span: parsetree.Span{Start: 2, End: 2}},
+		},
+	}, tree)
+
+	tree, err = ll1.Parse("(")
+
+	assert.EqualError(t, err, "1:2: unexpected end of the string while parsing 'S', expected ')'")
+	assert.Nil(t, tree)
+}
+
+func TestParseRegexesReplaces(t *testing.T) {
+	ll1, err := NewLL1(
+		[]rune{'S', 'T'},
+		[]rune{'d', '+'},
+		'S',
+		LL1Table{
+			'S': {'d': {'d', 'T'}},
+			'T': {
+				'+': {'+', 'd', 'T'},
+				'$': {},
+			},
+		},
+		[]RegexReplace{{Regex: "\\d+", Replacement: "d"}},
+	)
+	assert.Equal(t, nil, err)
+
+	tree, err := ll1.Parse("12+3")
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "S [0,3)\n  d \"d\" [0,1)\n  T [1,3)\n    + \"+\" [1,2)\n    d \"d\" [2,3)\n    T [3,3)\n      ε\n", tree.String())
+}
+
+func TestParseLexer(t *testing.T) {
+	lex, err := lexer.NewLexer([]lexer.Rule{
+		{Kind: "num", Regex: "\\d+"},
+		{Kind: "plus", Regex: "\\+"},
+		{Regex: "\\s+", Skip: true},
+	})
+	assert.Equal(t, nil, err)
+
+	ll1, err := NewLL1WithLexer(
+		[]grammar.Symbol{"Sum", "Rest"},
+		[]grammar.Symbol{"num", "plus"},
+		"Sum",
+		Table{
+			"Sum": {"num": {"num", "Rest"}},
+			"Rest": {
+				"plus": {"plus", "num", "Rest"},
+				"$":    {},
+			},
+		},
+		lex,
+	)
+	assert.Equal(t, nil, err)
+
+	tree, err := ll1.Parse("12 + 345")
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Sum [0,8)\n  num \"12\" [0,2)\n  Rest [3,8)\n    plus \"+\" [3,4)\n    num \"345\" [5,8)\n    Rest [8,8)\n      ε\n", tree.String())
+
+	assert.EqualError(t, ll1.Solve("12 7"), "1:4: unexpected '7' while parsing 'Rest', expected 'plus' or end of the string")
+	assert.EqualError(t, ll1.Solve("12 +"), "1:5: unexpected end of the string while parsing 'Rest', expected 'num'")
+	assert.Equal(t, fmt.Errorf("the character 'd' at 1:1 does not start any token"), ll1.Solve("d"))
+
+	_, err = NewLL1WithLexer([]grammar.Symbol{"Sum"}, []grammar.Symbol{"plus"}, "Sum", Table{}, lex)
+	assert.Equal(t, fmt.Errorf("the token kind 'num' is not in the terminal alphabet"), err)
+}
+
+func TestSyntaxError(t *testing.T) {
+	ll1, err := NewLL1(
+		[]rune{'S', 'T'},
+		[]rune{'a', '+'},
+		'S',
+		LL1Table{
+			'S': {'a': {'a', 'T'}},
+			'T': {
+				'+': {'+', 'a', 'T'},
+				'$': {},
+			},
+		},
+		nil,
+	)
+	assert.Equal(t, nil, err)
+
+	err = ll1.Solve("a+a+b")
+
+	var syntaxError *SyntaxError
+	assert.True(t, errors.As(err, &syntaxError))
+	assert.Equal(t, &SyntaxError{
+		Input:       "a+a+b",
+		Token:       lexer.Token{Kind: "b", Lexeme: "b", Pos: lexer.Position{Offset: 4, Line: 1, Column: 5}},
+		Nonterminal: "T",
+		Expected:    []grammar.Symbol{"a"},
+	}, syntaxError)
+	assert.Equal(t, "1:5: unexpected 'b' while parsing 'T', expected 'a'", err.Error())
+	assert.Equal(t, "a+a+b\n    ^\n", syntaxError.Snippet())
+
+	err = ll1.Solve("aa")
+
+	assert.True(t, errors.As(err, &syntaxError))
+	assert.Equal(t, grammar.Symbol("T"), syntaxError.Nonterminal)
+	assert.Equal(t, []grammar.Symbol{"+", "$"}, syntaxError.Expected)
+	assert.Equal(t, "1:2: unexpected 'a' while parsing 'T', expected '+' or end of the string", err.Error())
+
+	err = ll1.Solve("a+")
+
+	assert.True(t, errors.As(err, &syntaxError))
+	assert.Equal(t, lexer.Token{Kind: "$", Pos: lexer.Position{Offset: 2, Line: 1, Column: 3}}, syntaxError.Token)
+	assert.Equal(t, "1:3: unexpected end of the string while parsing 'T', expected 'a'", err.Error())
+}
+
+func TestSolveEndOfString(t *testing.T) {
+	// The nonterminals left on the stack are expanded with the end marker once the whole string is read
+	ll1, err := NewLL1([]rune{'S'}, []rune{'(', ')'}, 'S', LL1Table{'S': {'(': {'(', 'S', ')', 'S'}, ')': {}, '$': {}}}, nil)
+	assert.Equal(t, nil, err)
+
+	var tests = []struct {
+		str     string
+		isValid bool
+	}{
+		{"", true},
+		{"()", true},
+		{"(())()", true},
+		{"(()", false},
+		{"())", false},
+	}
+
+	for _, tt := range tests {
+		if tt.isValid {
+			assert.Nil(t, ll1.Solve(tt.str), "string '%v'", tt.str)
+		} else {
+			assert.Error(t, ll1.Solve(tt.str), "string '%v'", tt.str)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package ll1
+
+import (
+	"flfa/grammar"
+	"flfa/lexer"
+	"flfa/parsetree"
+	"fmt"
+	"sort"
+)
+
+/*
+	Validates and solves a ll1 given a string, recovering from syntax errors to report all of them in one pass.
+	Recovery is in panic mode: a terminal that does not match is treated as missing,
+	and a nonterminal without a push string skips tokens until one it can start with, or is dropped at a token in its FOLLOW set.
+	The parse tree is only returned if there are no syntax errors, and other errors stop the parse.
+*/
+func (ll1 *ll1) ParseRecover(str string) (*parsetree.Node, []*SyntaxError, error) {
+	err := ll1.validate()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokens, str, err := ll1.tokenize(str)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	follow, err := ll1.follow()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := &parsetree.Node{Symbol: string(ll1.startSymbol)}
+	stack := []*parsetree.Node{root}
+	heads := make(map[*parsetree.Node]grammar.Symbol)
+	var syntaxErrors []*SyntaxError
+
+	// Reports a syntax error, dropping the ones at the position of the previous error to avoid cascades
+	report := func(token lexer.Token, top *parsetree.Node) {
+		if len(syntaxErrors) != 0 && syntaxErrors[len(syntaxErrors)-1].Token.Pos == token.Pos {
+			return
+		}
+
+		syntaxErrors = append(syntaxErrors, ll1.syntaxError(str, token, top, heads))
+	}
+
+	i := 0
+	lookahead := func() (grammar.Symbol, lexer.Token) {
+		if i < len(tokens) {
+			return grammar.Symbol(tokens[i].Kind), tokens[i]
+		}
+
+		return endMarker, endToken(str)
+	}
+
+	for len(stack) != 0 || i < len(tokens) {
+		terminal, token := lookahead()
+
+		// Skips a token left after the stack is empty and parses the rest of the string from the start again
+		if len(stack) == 0 {
+			report(token, nil)
+			i++
+
+			if i < len(tokens) {
+				stack = []*parsetree.Node{{Symbol: string(ll1.startSymbol)}}
+			}
+
+			continue
+		}
+
+		if i < len(tokens) && ll1.validateTerminal(terminal) != nil {
+			report(token, stack[0])
+			i++
+			continue
+		}
+
+		top := stack[0]
+
+		if top.Terminal {
+			if i < len(tokens) && top.Symbol == string(terminal) {
+				top.Lexeme = token.Lexeme
+				top.Span = parsetree.Span{Start: token.Pos.Offset, End: token.Pos.Offset + len(token.Lexeme)}
+				i++
+			} else {
+				report(token, top)
+			}
+
+			stack = stack[1:]
+			continue
+		}
+
+		nonterminal := grammar.Symbol(top.Symbol)
+		if pushString, ok := ll1.table[nonterminal][terminal]; ok {
+			stack = append(ll1.expand(top, pushString, token.Pos.Offset, heads), stack[1:]...)
+			continue
+		}
+
+		report(token, top)
+
+		// Skips tokens until the nonterminal can start with one or it is in the nonterminal's FOLLOW set
+		for ; i < len(tokens); i++ {
+			terminal := grammar.Symbol(tokens[i].Kind)
+			if _, ok := ll1.table[nonterminal][terminal]; ok {
+				break
+			}
+
+			if follow[nonterminal][terminal] {
+				break
+			}
+		}
+
+		if terminal, _ := lookahead(); i < len(tokens) {
+			if _, ok := ll1.table[nonterminal][terminal]; ok {
+				continue
+			}
+		}
+
+		stack = stack[1:]
+	}
+
+	if len(syntaxErrors) != 0 {
+		return nil, syntaxErrors, nil
+	}
+
+	root.FitSpans()
+
+	return root, nil, nil
+}
+
+/*
+	Finds the FOLLOW sets of the grammar the ll1's table was made from.
+	The productions are the distinct push strings of the table, so tables written by hand work as well.
+*/
+func (ll1 *ll1) follow() (map[grammar.Symbol]grammar.SymbolSet, error) {
+	var productions []grammar.Production
+	seen := make(map[string]bool)
+
+	for _, nonterminal := range ll1.nonterminalAlphabet {
+		var terminals []grammar.Symbol
+		for terminal := range ll1.table[nonterminal] {
+			terminals = append(terminals, terminal)
+		}
+		sort.Slice(terminals, func(i, j int) bool { return terminals[i] < terminals[j] })
+
+		for _, terminal := range terminals {
+			production := grammar.Production{Head: nonterminal, Body: ll1.table[nonterminal][terminal]}
+
+			if !seen[production.String()] {
+				seen[production.String()] = true
+				productions = append(productions, production)
+			}
+		}
+	}
+
+	g, err := grammar.NewGrammar(ll1.nonterminalAlphabet, ll1.terminalAlphabet, ll1.startSymbol, productions)
+	if err != nil {
+		return nil, fmt.Errorf("the grammar of the ll1's table is invalid: %v", err)
+	}
+
+	return g.Follow(), nil
+}
@@ -0,0 +1,60 @@
+package ll1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRecover(t *testing.T) {
+	// Balanced parentheses with a hand written table, so the FOLLOW sets come from the table
+	ll1, err := NewLL1(
+		[]rune{'S'},
+		[]rune{'(', ')', '[', ']'},
+		'S',
+		LL1Table{
+			'S': {
+				'(': {'(', 'S', ')', 'S'},
+				'[': {'[', 'S', ']', 'S'},
+				')': {},
+				']': {},
+				'$': {},
+			},
+		},
+		nil,
+	)
+	assert.Equal(t, nil, err)
+
+	var tests = []struct {
+		str  string
+		want []string
+	}{
+		{"([])", nil},
+		{"(]", []string{"1:2: unexpected ']' while parsing 'S', expected ')'"}},
+		{"(()", []string{"1:4: unexpected end of the string while parsing 'S', expected ')'"}},
+		{
+			"())[x](",
+			[]string{
+				"1:3: unexpected ')' while parsing 'S', expected end of the string",
+				"1:5: unexpected 'x' while parsing 'S', expected '(' or ')' or '[' or ']' or end of the string",
+				"1:8: unexpected end of the string while parsing 'S', expected ')'",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tree, syntaxErrors, err := ll1.ParseRecover(tt.str)
+		assert.Equal(t, nil, err, "string '%v'", tt.str)
+
+		var messages []string
+		for _, syntaxError := range syntaxErrors {
+			messages = append(messages, syntaxError.Error())
+		}
+
+		assert.Equal(t, tt.want, messages, "string '%v'", tt.str)
+		assert.Equal(t, tt.want == nil, tree != nil, "string '%v'", tt.str)
+	}
+
+	// The fail-fast parse stops at the first error
+	assert.EqualError(t, ll1.Solve("())[x]("), "1:3: unexpected ')' while parsing 'S', expected end of the string")
+}
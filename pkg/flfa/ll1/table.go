@@ -0,0 +1,84 @@
+package ll1
+
+import (
+	"flfa/grammar"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+/*
+	Renders the LL(1) table as a text table with a row for every nonterminal and a column for every terminal.
+	Each cell holds the symbols pushed for that nonterminal and terminal separated by spaces, where 'ε' is the empty string.
+	Rows and columns are sorted by their symbol, so the output can be compared between runs.
+*/
+func (table Table) String() string {
+	return table.render(" ")
+}
+
+/*
+	Renders the LL(1) table as a text table, where each cell holds the string pushed for that nonterminal and terminal.
+*/
+func (ll1Table LL1Table) String() string {
+	return ll1Table.toTable().render("")
+}
+
+/*
+	Renders the table with the pushed symbols of every cell joined by a separator.
+	Sorting single character symbols as strings sorts them by their rune.
+*/
+func (table Table) render(separator string) string {
+	var nonterminals []grammar.Symbol
+	isTerminal := make(map[grammar.Symbol]bool)
+
+	for nonterminal, row := range table {
+		nonterminals = append(nonterminals, nonterminal)
+
+		for terminal := range row {
+			isTerminal[terminal] = true
+		}
+	}
+
+	var terminals []grammar.Symbol
+	for terminal := range isTerminal {
+		terminals = append(terminals, terminal)
+	}
+
+	sort.Slice(nonterminals, func(i, j int) bool { return nonterminals[i] < nonterminals[j] })
+	sort.Slice(terminals, func(i, j int) bool { return terminals[i] < terminals[j] })
+
+	var builder strings.Builder
+	writer := tabwriter.NewWriter(&builder, 0, 0, 1, ' ', tabwriter.Debug)
+
+	writer.Write([]byte(" "))
+	for _, terminal := range terminals {
+		writer.Write([]byte("\t " + string(terminal) + " "))
+	}
+	writer.Write([]byte("\t\n"))
+
+	for _, nonterminal := range nonterminals {
+		writer.Write([]byte(" " + string(nonterminal) + " "))
+
+		for _, terminal := range terminals {
+			cell := ""
+			if pushString, ok := table[nonterminal][terminal]; ok {
+				var symbols []string
+				for _, symbol := range pushString {
+					symbols = append(symbols, string(symbol))
+				}
+
+				cell = strings.Join(symbols, separator)
+				if len(pushString) == 0 {
+					cell = "ε"
+				}
+			}
+
+			writer.Write([]byte("\t " + cell + " "))
+		}
+		writer.Write([]byte("\t\n"))
+	}
+
+	writer.Flush()
+
+	return builder.String()
+}
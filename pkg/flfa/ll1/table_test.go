@@ -0,0 +1,66 @@
+package ll1
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLL1TableString(t *testing.T) {
+	ll1Table := LL1Table{
+		'S': {
+			'(': {'t', '$'},
+			'd': {'t', '$'},
+		},
+		't': {
+			'(': {'r', 'T'},
+			'd': {'r', 'T'},
+		},
+		'T': {
+			'+': {'+', 'r', 'T'},
+			'-': {'-', 'r', 'T'},
+			')': {},
+			'$': {},
+		},
+		'r': {
+			'(': {'v', 'R'},
+			'd': {'v', 'R'},
+		},
+		'R': {
+			'+': {},
+			'-': {},
+			'*': {'*', 'v', 'R'},
+			'/': {'/', 'v', 'R'},
+			')': {},
+			'$': {},
+		},
+		'v': {
+			'(': {'(', 't', ')'},
+			'd': {'d'},
+		},
+	}
+
+	golden, err := ioutil.ReadFile("testdata/arithmetic.txt")
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, string(golden), ll1Table.String())
+}
+
+func TestTableString(t *testing.T) {
+	table := Table{
+		"Stmt": {
+			"if":    {"if", "id", "then", "Stmt"},
+			"print": {"print", "id"},
+		},
+		"Else": {
+			"else": {"else", "Stmt"},
+			"$":    {},
+		},
+	}
+
+	assert.Equal(t, ""+
+		"       | $  | else       | if               | print     |\n"+
+		" Else  | ε  | else Stmt  |                  |           |\n"+
+		" Stmt  |    |            | if id then Stmt  | print id  |\n", table.String())
+}
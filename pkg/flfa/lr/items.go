@@ -0,0 +1,269 @@
+package lr
+
+import (
+	"flfa/grammar"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+/*
+	An LR(0) item, a production with a dot marking how much of its body has been read.
+*/
+type Item struct {
+	Production grammar.Production
+	Dot        int
+}
+
+/*
+	Formats the item as 'A -> b . C', where the dot of an empty body is written 'A -> .'.
+*/
+func (item Item) String() string {
+	symbols := make([]string, 0, len(item.Production.Body)+1)
+	for i, symbol := range item.Production.Body {
+		if i == item.Dot {
+			symbols = append(symbols, ".")
+		}
+
+		symbols = append(symbols, string(symbol))
+	}
+
+	if item.Dot == len(item.Production.Body) {
+		symbols = append(symbols, ".")
+	}
+
+	return fmt.Sprintf("%v -> %v", item.Production.Head, strings.Join(symbols, " "))
+}
+
+/*
+	An item of the augmented grammar, with the production given by its index.
+	The lookahead is only set for LR(1) items.
+*/
+type item struct {
+	production int
+	dot        int
+	lookahead  grammar.Symbol
+}
+
+/*
+	A grammar augmented with a new start symbol S' and the production S' -> S, which is the production 0.
+	The nullable nonterminals and FIRST sets are found once, since every LR(1) closure looks them up.
+*/
+type augmented struct {
+	grammar     grammar.Grammar
+	start       grammar.Symbol
+	productions []grammar.Production
+	symbols     []grammar.Symbol
+	nullable    grammar.SymbolSet
+	first       map[grammar.Symbol]grammar.SymbolSet
+}
+
+/*
+	Augments a grammar, naming the new start symbol after the old one with primes added until the name is unused.
+*/
+func augment(g grammar.Grammar) (augmented, error) {
+	if g.IsTerminal(grammar.EndMarker) {
+		return augmented{}, fmt.Errorf("the end marker '%v' cannot be a terminal, since it is added after the string", grammar.EndMarker)
+	}
+
+	start := g.Start() + "'"
+	for g.IsNonterminal(start) || g.IsTerminal(start) {
+		start += "'"
+	}
+
+	productions := append([]grammar.Production{{Head: start, Body: []grammar.Symbol{g.Start()}}}, g.Productions()...)
+
+	augmentedGrammar, err := grammar.NewGrammar(append([]grammar.Symbol{start}, g.Nonterminals()...), g.Terminals(), start, productions)
+	if err != nil {
+		return augmented{}, err
+	}
+
+	symbols := append(augmentedGrammar.Nonterminals(), augmentedGrammar.Terminals()...)
+
+	return augmented{augmentedGrammar, start, productions, symbols, augmentedGrammar.Nullable(), augmentedGrammar.First()}, nil
+}
+
+/*
+	Finds the FIRST set of a string of symbols and if the string derives the empty string.
+*/
+func (a *augmented) firstOf(symbols []grammar.Symbol) (grammar.SymbolSet, bool) {
+	first := make(grammar.SymbolSet)
+
+	for _, symbol := range symbols {
+		for terminal := range a.first[symbol] {
+			first[terminal] = true
+		}
+
+		if !a.nullable[symbol] {
+			return first, false
+		}
+	}
+
+	return first, true
+}
+
+/*
+	Gets the symbol after the dot of an item, if there is one.
+*/
+func (a *augmented) next(item item) (grammar.Symbol, bool) {
+	body := a.productions[item.production].Body
+	if item.dot == len(body) {
+		return "", false
+	}
+
+	return body[item.dot], true
+}
+
+/*
+	Gets the exported form of an item.
+*/
+func (a *augmented) export(item item) Item {
+	return Item{a.productions[item.production], item.dot}
+}
+
+/*
+	Finds the closure of LR(0) or LR(1) items, adding the items B -> . γ for every item with the dot before B.
+	The lookaheads of LR(1) items are the FIRST set of what follows B, followed by the item's lookahead if that is nullable.
+*/
+func (a *augmented) closure(items []item, isLR1 bool) []item {
+	closure := append([]item(nil), items...)
+	isAdded := make(map[item]bool)
+	for _, item := range items {
+		isAdded[item] = true
+	}
+
+	for i := 0; i < len(closure); i++ {
+		symbol, ok := a.next(closure[i])
+		if !ok || !a.grammar.IsNonterminal(symbol) {
+			continue
+		}
+
+		lookaheads := []grammar.Symbol{""}
+		if isLR1 {
+			rest := a.productions[closure[i].production].Body[closure[i].dot+1:]
+			first, isNullable := a.firstOf(rest)
+			if isNullable {
+				first[closure[i].lookahead] = true
+			}
+
+			lookaheads = first.Sorted()
+		}
+
+		for j, production := range a.productions {
+			if production.Head != symbol {
+				continue
+			}
+
+			for _, lookahead := range lookaheads {
+				newItem := item{j, 0, lookahead}
+				if !isAdded[newItem] {
+					isAdded[newItem] = true
+					closure = append(closure, newItem)
+				}
+			}
+		}
+	}
+
+	sortItems(closure)
+
+	return closure
+}
+
+/*
+	Finds the items reached from a set of items by reading a symbol.
+*/
+func (a *augmented) goTo(items []item, symbol grammar.Symbol, isLR1 bool) []item {
+	var moved []item
+	for _, item := range items {
+		if next, ok := a.next(item); ok && next == symbol {
+			item.dot++
+			moved = append(moved, item)
+		}
+	}
+
+	if len(moved) == 0 {
+		return nil
+	}
+
+	return a.closure(moved, isLR1)
+}
+
+/*
+	Builds the canonical collection of LR(0) or LR(1) item sets, numbered in the order they are found.
+	Transitions are explored on nonterminals and then terminals in the grammar's order, so the numbering is stable.
+*/
+func (a *augmented) collection(isLR1 bool) ([][]item, []map[grammar.Symbol]int) {
+	start := a.closure([]item{{0, 0, ""}}, isLR1)
+	if isLR1 {
+		start = a.closure([]item{{0, 0, grammar.EndMarker}}, isLR1)
+	}
+
+	states := [][]item{start}
+	transitions := []map[grammar.Symbol]int{{}}
+	indices := map[string]int{key(start): 0}
+
+	for i := 0; i < len(states); i++ {
+		for _, symbol := range a.symbols {
+			moved := a.goTo(states[i], symbol, isLR1)
+			if moved == nil {
+				continue
+			}
+
+			j, ok := indices[key(moved)]
+			if !ok {
+				j = len(states)
+				indices[key(moved)] = j
+				states = append(states, moved)
+				transitions = append(transitions, map[grammar.Symbol]int{})
+			}
+
+			transitions[i][symbol] = j
+		}
+	}
+
+	return states, transitions
+}
+
+/*
+	Gets the LR(0) core of a set of items, dropping their lookaheads.
+*/
+func core(items []item) []item {
+	var core []item
+	for _, item := range items {
+		item.lookahead = ""
+		if len(core) == 0 || core[len(core)-1] != item {
+			core = append(core, item)
+		}
+	}
+
+	return core
+}
+
+/*
+	Sorts items by production, dot and lookahead.
+*/
+func sortItems(items []item) {
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].production != items[j].production {
+			return items[i].production < items[j].production
+		}
+
+		if items[i].dot != items[j].dot {
+			return items[i].dot < items[j].dot
+		}
+
+		return items[i].lookahead < items[j].lookahead
+	})
+}
+
+/*
+	Gets a key identifying a sorted set of items.
+*/
+func key(items []item) string {
+	var builder strings.Builder
+	for _, item := range items {
+		fmt.Fprintf(&builder, "%v.%v.%v;", item.production, item.dot, item.lookahead)
+	}
+
+	return builder.String()
+}
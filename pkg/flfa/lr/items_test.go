@@ -0,0 +1,118 @@
+package lr
+
+import (
+	"flfa/grammar"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/*
+	Creates the left recursive arithmetic grammar.
+*/
+func newExpressionGrammar(t *testing.T) grammar.Grammar {
+	t.Helper()
+
+	g, err := grammar.NewGrammar(
+		[]grammar.Symbol{"E", "T", "F"},
+		[]grammar.Symbol{"+", "*", "(", ")", "id"},
+		"E",
+		[]grammar.Production{
+			{Head: "E", Body: []grammar.Symbol{"E", "+", "T"}},
+			{Head: "E", Body: []grammar.Symbol{"T"}},
+			{Head: "T", Body: []grammar.Symbol{"T", "*", "F"}},
+			{Head: "T", Body: []grammar.Symbol{"F"}},
+			{Head: "F", Body: []grammar.Symbol{"(", "E", ")"}},
+			{Head: "F", Body: []grammar.Symbol{"id"}},
+		},
+	)
+	require.NoError(t, err)
+
+	return g
+}
+
+func TestItemString(t *testing.T) {
+	var tests = []struct {
+		item Item
+		want string
+	}{
+		{Item{grammar.Production{Head: "E", Body: []grammar.Symbol{"E", "+", "T"}}, 0}, "E -> . E + T"},
+		{Item{grammar.Production{Head: "E", Body: []grammar.Symbol{"E", "+", "T"}}, 2}, "E -> E + . T"},
+		{Item{grammar.Production{Head: "E", Body: []grammar.Symbol{"E", "+", "T"}}, 3}, "E -> E + T ."},
+		{Item{grammar.Production{Head: "A", Body: []grammar.Symbol{}}, 0}, "A -> ."},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.item.String())
+	}
+}
+
+func TestCollection(t *testing.T) {
+	a, err := augment(newExpressionGrammar(t))
+	assert.Equal(t, nil, err)
+
+	// The canonical LR(0) collection of the expression grammar has 12 states
+	states, transitions := a.collection(false)
+	assert.Equal(t, 12, len(states))
+
+	var start []string
+	for _, item := range states[0] {
+		start = append(start, a.export(item).String())
+	}
+
+	assert.Equal(t, []string{
+		"E' -> . E",
+		"E -> . E + T",
+		"E -> . T",
+		"T -> . T * F",
+		"T -> . F",
+		"F -> . ( E )",
+		"F -> . id",
+	}, start)
+
+	accepting := transitions[0]["E"]
+	assert.Equal(t, []item{{0, 1, ""}, {1, 1, ""}}, states[accepting])
+
+	// Merging the LR(1) states by core gives back the LR(0) states
+	lr1States, _ := a.collection(true)
+	assert.Greater(t, len(lr1States), len(states))
+
+	cores := make(map[string]bool)
+	for _, lr1State := range lr1States {
+		cores[key(core(lr1State))] = true
+	}
+
+	assert.Equal(t, len(states), len(cores))
+}
+
+func TestAugment(t *testing.T) {
+	g, err := grammar.NewGrammar([]grammar.Symbol{"S", "S'"}, []grammar.Symbol{"a"}, "S", []grammar.Production{{Head: "S", Body: []grammar.Symbol{"a"}}})
+	assert.Equal(t, nil, err)
+
+	a, err := augment(g)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, grammar.Symbol("S''"), a.start)
+
+	g, err = grammar.NewGrammar([]grammar.Symbol{"S"}, []grammar.Symbol{"a", "$"}, "S", []grammar.Production{{Head: "S", Body: []grammar.Symbol{"a", "$"}}})
+	assert.Equal(t, nil, err)
+
+	_, err = augment(g)
+	assert.EqualError(t, err, "the end marker '$' cannot be a terminal, since it is added after the string")
+}
+
+func TestFirstOf(t *testing.T) {
+	a, err := augment(newExpressionGrammar(t))
+	assert.Equal(t, nil, err)
+
+	// The sets found once agree with the grammar's for every rest of a body
+	for _, production := range a.productions {
+		for dot := 0; dot <= len(production.Body); dot++ {
+			wantFirst, wantIsNullable := a.grammar.FirstOf(production.Body[dot:])
+
+			first, isNullable := a.firstOf(production.Body[dot:])
+			assert.Equal(t, wantFirst, first, "%v", Item{production, dot})
+			assert.Equal(t, wantIsNullable, isNullable, "%v", Item{production, dot})
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package lr
+
+import (
+	"flfa/grammar"
+	"flfa/internal/diagnostic"
+	"flfa/lexer"
+	"flfa/parsetree"
+	"fmt"
+)
+
+/*
+	A table-driven shift-reduce parser.
+*/
+type Parser struct {
+	table Table
+	lexer *lexer.Lexer
+}
+
+/*
+	A syntax error found by the parser, which callers can inspect with errors.As.
+	The token is the end token, with the end marker as its kind and an empty lexeme, when the string ended too early.
+	The expected terminals are the ones with an action in the state, and hold the end marker when the string could have ended.
+*/
+type SyntaxError struct {
+	Input    string
+	Token    lexer.Token
+	State    int
+	Expected []grammar.Symbol
+}
+
+/*
+	Creates a parser from an action and goto table and validates it.
+	The parser reads the tokens of the lexer, or one token per character if the lexer is nil.
+	Every kind of token the lexer makes must be a terminal of the table's grammar.
+	If the parser fails validation, then an empty parser is returned.
+*/
+func NewParser(table Table, lexer *lexer.Lexer) (Parser, error) {
+	err := table.validate()
+	if err != nil {
+		return Parser{}, err
+	}
+
+	if lexer != nil {
+		terminals := make(grammar.SymbolSet)
+		for _, terminal := range table.Terminals {
+			terminals[terminal] = true
+		}
+
+		for _, kind := range lexer.Kinds() {
+			if !terminals[grammar.Symbol(kind)] {
+				return Parser{}, fmt.Errorf("the token kind '%v' is not in the terminal alphabet", kind)
+			}
+		}
+	}
+
+	return Parser{table, lexer}, nil
+}
+
+/*
+	Gets the parser's table.
+*/
+func (parser *Parser) Table() Table {
+	return parser.table
+}
+
+/*
+	Parses a string, building the same parse tree as the LL(1) parser.
+	Every nonterminal node has a child for each symbol of the production it was reduced with,
+	and every terminal leaf holds the text it matched and its span in the string.
+	The end marker is read after the last token, and the first syntax error stops the parse.
+*/
+func (parser *Parser) Parse(str string) (*parsetree.Node, error) {
+	var tokens []lexer.Token
+	if parser.lexer != nil {
+		var err error
+		tokens, err = parser.lexer.Tokenize(str)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		tokens = lexer.Runes(str)
+	}
+
+	tokens = append(tokens, lexer.Token{Kind: lexer.Kind(grammar.EndMarker), Pos: lexer.EndOf(str)})
+
+	states := []int{0}
+	var nodes []*parsetree.Node
+
+	for i := 0; ; {
+		token := tokens[i]
+		state := states[len(states)-1]
+
+		action, ok := parser.table.Action[state][grammar.Symbol(token.Kind)]
+		if !ok || (token.Kind == lexer.Kind(grammar.EndMarker) && i != len(tokens)-1) {
+			return nil, parser.syntaxError(str, token, state)
+		}
+
+		switch action.Kind {
+		case Shift:
+			node := &parsetree.Node{
+				Symbol:   string(token.Kind),
+				Terminal: true,
+				Lexeme:   token.Lexeme,
+				Span:     parsetree.Span{Start: token.Pos.Offset, End: token.Pos.Offset + len(token.Lexeme)},
+			}
+
+			states = append(states, action.State)
+			nodes = append(nodes, node)
+			i++
+		case Reduce:
+			n := len(action.Production.Body)
+			node := &parsetree.Node{
+				Symbol: string(action.Production.Head),
+				Span:   parsetree.Span{Start: token.Pos.Offset, End: token.Pos.Offset},
+			}
+
+			if n >= len(states) {
+				return nil, fmt.Errorf("the reduction of '%v' in the state '%v' pops more symbols than the stack holds", action.Production, state)
+			}
+
+			if n != 0 {
+				node.Children = append([]*parsetree.Node(nil), nodes[len(nodes)-n:]...)
+			}
+
+			states = states[:len(states)-n]
+			nodes = nodes[:len(nodes)-n]
+
+			next, ok := parser.table.Goto[states[len(states)-1]][action.Production.Head]
+			if !ok {
+				return nil, fmt.Errorf("the table has no goto from the state '%v' on '%v'", states[len(states)-1], action.Production.Head)
+			}
+
+			states = append(states, next)
+			nodes = append(nodes, node)
+		case Accept:
+			if len(nodes) != 1 {
+				return nil, fmt.Errorf("the table accepts in the state '%v' without a single tree on the stack", state)
+			}
+
+			root := nodes[0]
+			root.FitSpans()
+
+			return root, nil
+		}
+	}
+}
+
+func (err *SyntaxError) Error() string {
+	return diagnostic.Message(err.Token, "", err.Expected)
+}
+
+/*
+	Renders the line of the input holding the error with carets under the offending token.
+*/
+func (err *SyntaxError) Snippet() string {
+	return lexer.Snippet(err.Input, err.Token)
+}
+
+/*
+	Creates the syntax error of reading a token in a state, expecting the terminals with an action sorted with the end marker last.
+*/
+func (parser *Parser) syntaxError(str string, token lexer.Token, state int) *SyntaxError {
+	var expected []grammar.Symbol
+	for terminal := range parser.table.Action[state] {
+		expected = append(expected, terminal)
+	}
+
+	diagnostic.SortExpected(expected)
+
+	return &SyntaxError{str, token, state, expected}
+}
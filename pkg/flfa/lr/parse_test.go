@@ -0,0 +1,168 @@
+package lr
+
+import (
+	"errors"
+	"flfa/grammar"
+	"flfa/internal/testutil"
+	"flfa/lexer"
+	"flfa/ll1"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	table, err := LALR(newExpressionGrammar(t))
+	assert.Equal(t, nil, err)
+
+	lex := testutil.ExpressionLexer(t)
+	parser, err := NewParser(table, &lex)
+	assert.Equal(t, nil, err)
+
+	tree, err := parser.Parse("a + b * c")
+
+	// The operators are left associative and '*' takes precedence over '+'
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `E [0,9)
+  E [0,1)
+    T [0,1)
+      F [0,1)
+        id "a" [0,1)
+  + "+" [2,3)
+  T [4,9)
+    T [4,5)
+      F [4,5)
+        id "b" [4,5)
+    * "*" [6,7)
+    F [8,9)
+      id "c" [8,9)
+`, tree.String())
+}
+
+func TestParseSameTreeAsLL1(t *testing.T) {
+	// A grammar that is both LL(1) and SLR(1), with an empty production
+	g, err := grammar.NewGrammar(
+		[]grammar.Symbol{"S"},
+		[]grammar.Symbol{"(", ")"},
+		"S",
+		[]grammar.Production{
+			{Head: "S", Body: []grammar.Symbol{"(", "S", ")", "S"}},
+			{Head: "S", Body: []grammar.Symbol{}},
+		},
+	)
+	assert.Equal(t, nil, err)
+
+	ll1Parser, err := ll1.NewLL1FromGrammar(g, nil)
+	assert.Equal(t, nil, err)
+
+	table, err := SLR(g)
+	assert.Equal(t, nil, err)
+
+	parser, err := NewParser(table, nil)
+	assert.Equal(t, nil, err)
+
+	for _, str := range []string{"", "()", "(())()", "((()))"} {
+		want, err := ll1Parser.Parse(str)
+		assert.Equal(t, nil, err, "string '%v'", str)
+
+		tree, err := parser.Parse(str)
+		assert.Equal(t, nil, err, "string '%v'", str)
+		assert.Equal(t, want, tree, "string '%v'", str)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	table, err := SLR(newExpressionGrammar(t))
+	assert.Equal(t, nil, err)
+
+	lex := testutil.ExpressionLexer(t)
+	parser, err := NewParser(table, &lex)
+	assert.Equal(t, nil, err)
+
+	var tests = []struct {
+		str  string
+		want string
+	}{
+		{"a + * b", "1:5: unexpected '*', expected '(' or 'id'"},
+		{"(a + b", "1:7: unexpected end of the string, expected ')' or '+'"},
+		{"a b", "1:3: unexpected 'b', expected ')' or '*' or '+' or end of the string"},
+		{"a # b", "the character '#' at 1:3 does not start any token"},
+	}
+
+	for _, tt := range tests {
+		_, err := parser.Parse(tt.str)
+		assert.EqualError(t, err, tt.want, "string '%v'", tt.str)
+	}
+
+	_, err = parser.Parse("a +\n  * b")
+
+	var syntaxError *SyntaxError
+	assert.True(t, errors.As(err, &syntaxError))
+	assert.Equal(t, lexer.Token{Kind: "*", Lexeme: "*", Pos: lexer.Position{Offset: 6, Line: 2, Column: 3}}, syntaxError.Token)
+	assert.Equal(t, "  * b\n  ^\n", syntaxError.Snippet())
+
+	// A character that is the end marker is not the end of the string
+	parser, err = NewParser(table, nil)
+	assert.Equal(t, nil, err)
+	_, err = parser.Parse("$")
+	assert.EqualError(t, err, "1:1: unexpected '$', expected '(' or 'id'")
+}
+
+func TestNewParser(t *testing.T) {
+	var tests = []struct {
+		table Table
+		want  error
+	}{
+		{Table{}, fmt.Errorf("the table must have at least one state")},
+		{Table{Action: []map[grammar.Symbol]Action{{}}}, fmt.Errorf("the goto table has '0' states, but the action table has '1'")},
+		{
+			Table{Action: []map[grammar.Symbol]Action{{"a": {Kind: Shift, State: 1}}}, Goto: []map[grammar.Symbol]int{{}}},
+			fmt.Errorf("the state '1' shifted to from the state '0' on 'a' is not in the table"),
+		},
+		{
+			Table{Action: []map[grammar.Symbol]Action{{}}, Goto: []map[grammar.Symbol]int{{"S": -1}}},
+			fmt.Errorf("the state '-1' gone to from the state '0' on 'S' is not in the table"),
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := NewParser(tt.table, nil)
+		assert.Equal(t, tt.want, err)
+	}
+
+	// The lexer makes a token kind that is not a terminal of the grammar
+	table, err := SLR(newExpressionGrammar(t))
+	assert.Equal(t, nil, err)
+
+	lex, err := lexer.NewLexer([]lexer.Rule{{Kind: "id", Regex: "[a-z]+"}, {Kind: "num", Regex: "\\d+"}})
+	assert.Equal(t, nil, err)
+
+	_, err = NewParser(table, &lex)
+	assert.Equal(t, fmt.Errorf("the token kind 'num' is not in the terminal alphabet"), err)
+
+	// A table written by hand that reduces to a nonterminal without a goto fails instead of panicking
+	parser, err := NewParser(Table{
+		Action: []map[grammar.Symbol]Action{
+			{"a": {Kind: Shift, State: 1}},
+			{grammar.EndMarker: {Kind: Reduce, Production: grammar.Production{Head: "S", Body: []grammar.Symbol{"a"}}}},
+		},
+		Goto: []map[grammar.Symbol]int{{}, {}},
+	}, nil)
+	assert.Equal(t, nil, err)
+
+	_, err = parser.Parse("a")
+	assert.Equal(t, fmt.Errorf("the table has no goto from the state '0' on 'S'"), err)
+
+	// A table written by hand that reduces more symbols than are on the stack fails instead of panicking
+	parser, err = NewParser(Table{
+		Action: []map[grammar.Symbol]Action{
+			{"a": {Kind: Reduce, Production: grammar.Production{Head: "S", Body: []grammar.Symbol{"a", "a"}}}},
+		},
+		Goto: []map[grammar.Symbol]int{{"S": 0}},
+	}, nil)
+	assert.Equal(t, nil, err)
+
+	_, err = parser.Parse("a")
+	assert.Equal(t, fmt.Errorf("the reduction of 'S -> a a' in the state '0' pops more symbols than the stack holds"), err)
+}
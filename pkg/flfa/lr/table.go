@@ -0,0 +1,271 @@
+package lr
+
+import (
+	"flfa/grammar"
+	"fmt"
+	"strings"
+)
+
+type ActionKind int
+
+const (
+	Shift ActionKind = iota
+	Reduce
+	Accept
+)
+
+/*
+	An entry of the action table.
+	A shift moves to the state, and a reduce replaces the body of the production on the stack with its head.
+*/
+type Action struct {
+	Kind       ActionKind
+	State      int
+	Production grammar.Production
+}
+
+/*
+	The action and goto tables of an LR parser, with the items of every state and the terminals of the grammar.
+	The action table maps every state and lookahead terminal to an action, where the end marker is the lookahead at the end.
+	The goto table maps every state and nonterminal to the state entered after reducing to the nonterminal.
+*/
+type Table struct {
+	Action    []map[grammar.Symbol]Action
+	Goto      []map[grammar.Symbol]int
+	States    [][]Item
+	Terminals []grammar.Symbol
+}
+
+/*
+	A cell of an action table that more than one action is found for, with the items that call for the actions.
+*/
+type Conflict struct {
+	State    int
+	Terminal grammar.Symbol
+	Actions  []Action
+	Items    []Item
+}
+
+/*
+	The error returned for a grammar that does not fit the kind of table, holding every conflicting cell.
+*/
+type ConflictError struct {
+	Kind      string
+	Conflicts []Conflict
+}
+
+func (action Action) String() string {
+	switch action.Kind {
+	case Shift:
+		return fmt.Sprintf("shift %v", action.State)
+	case Reduce:
+		return fmt.Sprintf("reduce '%v'", action.Production)
+	}
+
+	return "accept"
+}
+
+func (err *ConflictError) Error() string {
+	var conflicts []string
+	for _, conflict := range err.Conflicts {
+		conflicts = append(conflicts, conflict.String())
+	}
+
+	return fmt.Sprintf("the grammar is not %v: %v", err.Kind, strings.Join(conflicts, "; "))
+}
+
+/*
+	Gets if the conflict is a shift/reduce or a reduce/reduce conflict.
+*/
+func (conflict Conflict) Kind() string {
+	for _, action := range conflict.Actions {
+		if action.Kind == Shift {
+			return "shift/reduce"
+		}
+	}
+
+	return "reduce/reduce"
+}
+
+func (conflict Conflict) String() string {
+	var actions []string
+	for _, action := range conflict.Actions {
+		actions = append(actions, action.String())
+	}
+
+	var items []string
+	for _, item := range conflict.Items {
+		items = append(items, fmt.Sprintf("'%v'", item))
+	}
+
+	return fmt.Sprintf("%v conflict in the state %v on '%v' between %v from the items %v", conflict.Kind(), conflict.State, conflict.Terminal, strings.Join(actions, " and "), strings.Join(items, " and "))
+}
+
+/*
+	Generates the SLR(1) table of a grammar from its LR(0) item sets.
+	A complete item A -> α . is reduced on every terminal in the FOLLOW set of A.
+	If the grammar is not SLR(1), then a *ConflictError holding every conflicting cell is returned.
+*/
+func SLR(g grammar.Grammar) (Table, error) {
+	a, err := augment(g)
+	if err != nil {
+		return Table{}, err
+	}
+
+	states, transitions := a.collection(false)
+	follow := a.grammar.Follow()
+
+	return a.table("SLR(1)", states, transitions, func(state int, item item) []grammar.Symbol {
+		return follow[a.productions[item.production].Head].Sorted()
+	})
+}
+
+/*
+	Generates the LALR(1) table of a grammar by merging the LR(1) item sets that share an LR(0) core.
+	A complete item is reduced on the lookaheads of the LR(1) items merged into it,
+	so the states are the same as in the SLR(1) table and fewer cells conflict.
+	If the grammar is not LALR(1), then a *ConflictError holding every conflicting cell is returned.
+*/
+func LALR(g grammar.Grammar) (Table, error) {
+	a, err := augment(g)
+	if err != nil {
+		return Table{}, err
+	}
+
+	states, transitions := a.collection(false)
+
+	indices := make(map[string]int, len(states))
+	for i, state := range states {
+		indices[key(state)] = i
+	}
+
+	// Merges the lookaheads of every LR(1) item into the LR(0) state with the same core
+	lookaheads := make([]map[item]grammar.SymbolSet, len(states))
+	for i := range lookaheads {
+		lookaheads[i] = make(map[item]grammar.SymbolSet)
+	}
+
+	lr1States, _ := a.collection(true)
+	for _, lr1State := range lr1States {
+		i := indices[key(core(lr1State))]
+
+		for _, lr1Item := range lr1State {
+			lr0Item := item{lr1Item.production, lr1Item.dot, ""}
+			if lookaheads[i][lr0Item] == nil {
+				lookaheads[i][lr0Item] = make(grammar.SymbolSet)
+			}
+
+			lookaheads[i][lr0Item][lr1Item.lookahead] = true
+		}
+	}
+
+	return a.table("LALR(1)", states, transitions, func(state int, item item) []grammar.Symbol {
+		return lookaheads[state][item].Sorted()
+	})
+}
+
+/*
+	Fills in the action and goto tables from the LR(0) item sets, reducing complete items on the given lookaheads.
+*/
+func (a *augmented) table(kind string, states [][]item, transitions []map[grammar.Symbol]int, reduceOn func(state int, item item) []grammar.Symbol) (Table, error) {
+	table := Table{
+		make([]map[grammar.Symbol]Action, len(states)),
+		make([]map[grammar.Symbol]int, len(states)),
+		make([][]Item, len(states)),
+		a.grammar.Terminals(),
+	}
+
+	var conflicts []Conflict
+
+	for i, state := range states {
+		table.Goto[i] = make(map[grammar.Symbol]int)
+		for symbol, j := range transitions[i] {
+			if a.grammar.IsNonterminal(symbol) {
+				table.Goto[i][symbol] = j
+			}
+		}
+
+		// Collects the actions of every lookahead with the items calling for them
+		actions := make(map[grammar.Symbol][]Action)
+		actionItems := make(map[grammar.Symbol][]Item)
+		add := func(terminal grammar.Symbol, action Action, item item) {
+			isNew := true
+			for _, possibleAction := range actions[terminal] {
+				if possibleAction.String() == action.String() {
+					isNew = false
+				}
+			}
+
+			if isNew {
+				actions[terminal] = append(actions[terminal], action)
+			}
+
+			actionItems[terminal] = append(actionItems[terminal], a.export(item))
+		}
+
+		for _, item := range state {
+			table.States[i] = append(table.States[i], a.export(item))
+
+			symbol, ok := a.next(item)
+			switch {
+			case ok && a.grammar.IsTerminal(symbol):
+				add(symbol, Action{Kind: Shift, State: transitions[i][symbol]}, item)
+			case !ok && item.production == 0:
+				add(grammar.EndMarker, Action{Kind: Accept}, item)
+			case !ok:
+				for _, terminal := range reduceOn(i, item) {
+					add(terminal, Action{Kind: Reduce, Production: a.productions[item.production]}, item)
+				}
+			}
+		}
+
+		table.Action[i] = make(map[grammar.Symbol]Action)
+		for _, terminal := range append(a.grammar.Terminals(), grammar.EndMarker) {
+			if len(actions[terminal]) == 0 {
+				continue
+			}
+
+			if len(actions[terminal]) > 1 {
+				conflicts = append(conflicts, Conflict{i, terminal, actions[terminal], actionItems[terminal]})
+				continue
+			}
+
+			table.Action[i][terminal] = actions[terminal][0]
+		}
+	}
+
+	if len(conflicts) != 0 {
+		return Table{}, &ConflictError{kind, conflicts}
+	}
+
+	return table, nil
+}
+
+/*
+	Validates that the table has a state to start in, a goto row for every state, and that its actions and gotos enter states of the table.
+*/
+func (table *Table) validate() error {
+	if len(table.Action) == 0 {
+		return fmt.Errorf("the table must have at least one state")
+	}
+
+	if len(table.Goto) != len(table.Action) {
+		return fmt.Errorf("the goto table has '%v' states, but the action table has '%v'", len(table.Goto), len(table.Action))
+	}
+
+	for i, actions := range table.Action {
+		for terminal, action := range actions {
+			if action.Kind == Shift && (action.State < 0 || action.State >= len(table.Action)) {
+				return fmt.Errorf("the state '%v' shifted to from the state '%v' on '%v' is not in the table", action.State, i, terminal)
+			}
+		}
+
+		for nonterminal, state := range table.Goto[i] {
+			if state < 0 || state >= len(table.Action) {
+				return fmt.Errorf("the state '%v' gone to from the state '%v' on '%v' is not in the table", state, i, nonterminal)
+			}
+		}
+	}
+
+	return nil
+}
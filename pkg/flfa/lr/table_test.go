@@ -0,0 +1,118 @@
+package lr
+
+import (
+	"errors"
+	"flfa/grammar"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/*
+	Creates the grammar of assignments through pointers, which is LALR(1) but not SLR(1).
+*/
+func newAssignmentGrammar(t *testing.T) grammar.Grammar {
+	t.Helper()
+
+	g, err := grammar.NewGrammar(
+		[]grammar.Symbol{"S", "L", "R"},
+		[]grammar.Symbol{"=", "*", "id"},
+		"S",
+		[]grammar.Production{
+			{Head: "S", Body: []grammar.Symbol{"L", "=", "R"}},
+			{Head: "S", Body: []grammar.Symbol{"R"}},
+			{Head: "L", Body: []grammar.Symbol{"*", "R"}},
+			{Head: "L", Body: []grammar.Symbol{"id"}},
+			{Head: "R", Body: []grammar.Symbol{"L"}},
+		},
+	)
+	require.NoError(t, err)
+
+	return g
+}
+
+func TestSLR(t *testing.T) {
+	table, err := SLR(newExpressionGrammar(t))
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 12, len(table.Action))
+	assert.Equal(t, Action{Kind: Shift, State: 5}, table.Action[0]["id"])
+	assert.Equal(t, 1, table.Goto[0]["E"])
+	assert.Equal(t, Action{Kind: Accept}, table.Action[1]["$"])
+
+	// T -> F . is reduced on FOLLOW(T)
+	f := table.Goto[0]["F"]
+	for _, terminal := range []grammar.Symbol{"+", "*", ")", "$"} {
+		assert.Equal(t, Action{Kind: Reduce, Production: grammar.Production{Head: "T", Body: []grammar.Symbol{"F"}}}, table.Action[f][terminal])
+	}
+	_, ok := table.Action[f]["id"]
+	assert.False(t, ok)
+}
+
+func TestSLRConflicts(t *testing.T) {
+	_, err := SLR(newAssignmentGrammar(t))
+
+	var conflictError *ConflictError
+	assert.True(t, errors.As(err, &conflictError))
+	assert.Equal(t, 1, len(conflictError.Conflicts))
+
+	conflict := conflictError.Conflicts[0]
+	assert.Equal(t, "shift/reduce", conflict.Kind())
+	assert.Equal(t, grammar.Symbol("="), conflict.Terminal)
+	assert.Equal(t, []Item{
+		{grammar.Production{Head: "S", Body: []grammar.Symbol{"L", "=", "R"}}, 1},
+		{grammar.Production{Head: "R", Body: []grammar.Symbol{"L"}}, 1},
+	}, conflict.Items)
+	assert.EqualError(t, err, "the grammar is not SLR(1): shift/reduce conflict in the state 2 on '=' between shift 6 and reduce 'R -> L' from the items 'S -> L . = R' and 'R -> L .'")
+}
+
+func TestLALR(t *testing.T) {
+	table, err := LALR(newAssignmentGrammar(t))
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, Action{Kind: Shift, State: 6}, table.Action[2]["="])
+	assert.Equal(t, Action{Kind: Reduce, Production: grammar.Production{Head: "R", Body: []grammar.Symbol{"L"}}}, table.Action[2]["$"])
+
+	// The LALR(1) table has the same states as the SLR(1) table
+	slrTable, err := SLR(newExpressionGrammar(t))
+	assert.Equal(t, nil, err)
+
+	lalrTable, err := LALR(newExpressionGrammar(t))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, slrTable.States, lalrTable.States)
+	assert.Equal(t, slrTable.Goto, lalrTable.Goto)
+}
+
+func TestLALRConflicts(t *testing.T) {
+	// The grammar is LR(1), but merging the states of A -> c . and B -> c . makes them conflict
+	g, err := grammar.NewGrammar(
+		[]grammar.Symbol{"S", "A", "B"},
+		[]grammar.Symbol{"a", "b", "c", "d", "e"},
+		"S",
+		[]grammar.Production{
+			{Head: "S", Body: []grammar.Symbol{"a", "A", "d"}},
+			{Head: "S", Body: []grammar.Symbol{"b", "B", "d"}},
+			{Head: "S", Body: []grammar.Symbol{"a", "B", "e"}},
+			{Head: "S", Body: []grammar.Symbol{"b", "A", "e"}},
+			{Head: "A", Body: []grammar.Symbol{"c"}},
+			{Head: "B", Body: []grammar.Symbol{"c"}},
+		},
+	)
+	assert.Equal(t, nil, err)
+
+	_, err = LALR(g)
+
+	var conflictError *ConflictError
+	assert.True(t, errors.As(err, &conflictError))
+	assert.Equal(t, "LALR(1)", conflictError.Kind)
+	assert.Equal(t, 2, len(conflictError.Conflicts))
+
+	for _, conflict := range conflictError.Conflicts {
+		assert.Equal(t, "reduce/reduce", conflict.Kind())
+		assert.Equal(t, []Item{
+			{grammar.Production{Head: "A", Body: []grammar.Symbol{"c"}}, 1},
+			{grammar.Production{Head: "B", Body: []grammar.Symbol{"c"}}, 1},
+		}, conflict.Items)
+	}
+}
@@ -1,32 +1,28 @@
+/*
+	The flfa command runs, checks and converts automata stored in JSON or YAML definition files.
+
+	Usage:
+
+		flfa <command> [flags] [arguments]
+
+	The commands are:
+
+		run       solves a machine for every input and reports if it is accepted
+		validate  checks that definition files hold valid machines
+		minimize  writes the minimal DFA of a machine
+		convert   writes a machine as a DFA or as a regular expression
+		export    writes a machine as a Graphviz DOT digraph
+		equiv     checks if two machines accept the same language
+
+	The exit status is 0 on success, 1 when an input is rejected, a machine is invalid or machines are not equivalent,
+	and 2 when the command cannot be carried out.
+*/
 package main
 
 import (
-	"flfa/nfa"
-	"fmt"
+	"os"
 )
 
 func main() {
-	states := []nfa.State{"q0", "q1"}
-	alphabet := []nfa.Symbol{'0', '1'}
-	delta := nfa.Delta{
-		"q0": {
-			'0': 0b01,
-			'1': 0b10,
-		},
-		"q1": {
-			'0': 0b01,
-			'1': 0b10,
-		},
-	}
-	startingStates := nfa.StatesBitMap(0b01)
-	acceptingStates := nfa.StatesBitMap(0b10)
-
-	nfa, err := nfa.NewNFA(states, alphabet, delta, startingStates, acceptingStates)
-	if err != nil {
-		fmt.Print(err)
-	}
-
-	finalStates, isAccepting, _ := nfa.Solve("1110")
-
-	fmt.Printf("%b, %v", finalStates, isAccepting)
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
 }
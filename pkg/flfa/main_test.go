@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	var tests = []struct {
+		args   []string
+		stdin  string
+		status int
+		stdout string
+	}{
+		{
+			[]string{"run", "machines/2mod7.yaml", "10", "1001"},
+			"",
+			exitSuccess,
+			"\"10\"\taccepted\n\"1001\"\taccepted\n",
+		},
+		{
+			[]string{"run", "machines/exercise-7.yaml"},
+			"aa\nab\r\n\nba\n",
+			exitFailure,
+			"\"aa\"\taccepted\n\"ab\"\trejected\n\"\"\trejected\n\"ba\"\trejected\n",
+		},
+		{
+			[]string{"run", "machines/mwgc.yaml", "gmx"},
+			"",
+			exitError,
+			"\"gmx\"\terror: the symbol 'x' is not within the alphabet\n",
+		},
+		{
+			[]string{"run", "-i", "machines/2mod7.yaml", "machines/2mod7.yaml", "10"},
+			"",
+			exitError,
+			"",
+		},
+		{
+			[]string{"validate", "machines/2mod7.yaml", "machines/exercise-7.yaml"},
+			"",
+			exitSuccess,
+			"machines/2mod7.yaml: valid dfa\nmachines/exercise-7.yaml: valid nfa\n",
+		},
+		{
+			[]string{"convert", "-to", "regex", "machines/exercise-7.yaml"},
+			"",
+			exitSuccess,
+			"b*a(b+a)*a[ab]*\n",
+		},
+		{
+			[]string{"equiv", "machines/2mod7.yaml", "machines/2mod7.yaml"},
+			"",
+			exitSuccess,
+			"equivalent\n",
+		},
+		{
+			[]string{"equiv", "machines/1mod3-2mod5.yaml", "machines/2mod7.yaml"},
+			"",
+			exitFailure,
+			"not equivalent: \"1\" is accepted by machines/1mod3-2mod5.yaml but not by machines/2mod7.yaml\n",
+		},
+		{
+			[]string{"export", "machines/2mod7.yaml", "extra"},
+			"",
+			exitError,
+			"",
+		},
+		{
+			[]string{"run", "-h"},
+			"",
+			exitSuccess,
+			"",
+		},
+		{
+			[]string{"validate", "-h"},
+			"",
+			exitSuccess,
+			"",
+		},
+		{
+			[]string{"equiv", "-h"},
+			"",
+			exitSuccess,
+			"",
+		},
+		{
+			[]string{"unknown"},
+			"",
+			exitError,
+			"",
+		},
+		{
+			[]string{},
+			"",
+			exitError,
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+
+		status := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+
+		assert.Equal(t, tt.status, status, "%v: %v", tt.args, stderr.String())
+		assert.Equal(t, tt.stdout, stdout.String(), "%v", tt.args)
+	}
+}
+
+func TestRunMinimize(t *testing.T) {
+	dir, err := os.MkdirTemp("", "flfa")
+	assert.Equal(t, nil, err)
+	defer os.RemoveAll(dir)
+
+	output := filepath.Join(dir, "exercise-7.json")
+
+	var stdout, stderr bytes.Buffer
+	status := run([]string{"minimize", "-o", output, "machines/exercise-7.yaml"}, nil, &stdout, &stderr)
+	assert.Equal(t, exitSuccess, status, stderr.String())
+
+	status = run([]string{"validate", output}, nil, &stdout, &stderr)
+	assert.Equal(t, exitSuccess, status, stdout.String())
+
+	stdout.Reset()
+	status = run([]string{"equiv", output, "machines/exercise-7.yaml"}, nil, &stdout, &stderr)
+	assert.Equal(t, exitSuccess, status, stderr.String())
+	assert.Equal(t, "equivalent\n", stdout.String())
+}
+
+func TestRunStreamsInputs(t *testing.T) {
+	stdinReader, stdinWriter := io.Pipe()
+	stdoutReader, stdoutWriter := io.Pipe()
+
+	status := make(chan int)
+	go func() {
+		var stderr bytes.Buffer
+		status <- run([]string{"run", "machines/exercise-7.yaml"}, stdinReader, stdoutWriter, &stderr)
+	}()
+
+	// Every result is printed before the next line is written
+	results := bufio.NewReader(stdoutReader)
+	for _, line := range []string{"aa", "ab"} {
+		_, err := io.WriteString(stdinWriter, line+"\n")
+		assert.Equal(t, nil, err)
+
+		result, err := results.ReadString('\n')
+		assert.Equal(t, nil, err)
+		assert.True(t, strings.HasPrefix(result, fmt.Sprintf("%q\t", line)), result)
+	}
+
+	stdinWriter.Close()
+	assert.Equal(t, exitFailure, <-status)
+}
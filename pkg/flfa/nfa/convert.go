@@ -0,0 +1,84 @@
+package nfa
+
+import (
+	"flfa/dfa"
+	"flfa/internal/statename"
+	"strings"
+)
+
+/*
+	Converts the NFA into an equivalent DFA using the subset construction.
+	Every subset is closed under epsilon moves, so the DFA has no epsilon moves.
+	Only the subsets of states reachable from the starting states are created, and each subset is named '{q0,q2}',
+	with the commas, braces, parentheses and backslashes in the names of the states escaped by a backslash.
+	If the NFA fails validation, then an empty DFA is returned.
+*/
+func (nfa *nfa) ToDFA() (dfa.DFA, error) {
+	err := nfa.validate()
+	if err != nil {
+		return dfa.DFA{}, err
+	}
+
+	alphabet := make([]dfa.Symbol, len(nfa.alphabet))
+	for i, symbol := range nfa.alphabet {
+		alphabet[i] = dfa.Symbol(symbol)
+	}
+
+	start := nfa.closure(nfa.startingStates)
+	visited := map[string]bool{start.String(): true}
+	queue := []StateSet{start}
+
+	var states []dfa.State
+	var acceptingStates []dfa.State
+	delta := make(dfa.Delta)
+
+	for len(queue) != 0 {
+		currentStates := queue[0]
+		queue = queue[1:]
+
+		state := nfa.subsetState(currentStates)
+		states = append(states, state)
+		delta[state] = make(map[dfa.Symbol]dfa.State, len(nfa.alphabet))
+
+		if currentStates.Intersects(nfa.acceptingStates) {
+			acceptingStates = append(acceptingStates, state)
+		}
+
+		for _, symbol := range nfa.alphabet {
+			nextStates := nfa.closure(nfa.move(currentStates, symbol))
+			delta[state][dfa.Symbol(symbol)] = nfa.subsetState(nextStates)
+
+			if !visited[nextStates.String()] {
+				visited[nextStates.String()] = true
+				queue = append(queue, nextStates)
+			}
+		}
+	}
+
+	return dfa.NewDFA(states, alphabet, delta, nfa.subsetState(start), acceptingStates)
+}
+
+/*
+	Decodes a state set into the names of its states.
+*/
+func (nfa *nfa) StateNames(stateSet StateSet) []State {
+	var names []State
+
+	for _, i := range stateSet.Indices() {
+		names = append(names, nfa.states[i])
+	}
+
+	return names
+}
+
+/*
+	Names the DFA state of a subset of the NFA's states, escaping the names so that different subsets get different names.
+*/
+func (nfa *nfa) subsetState(stateSet StateSet) dfa.State {
+	var names []string
+	for _, state := range nfa.StateNames(stateSet) {
+		names = append(names, statename.Escape(string(state)))
+	}
+
+	return dfa.State("{" + strings.Join(names, ",") + "}")
+}
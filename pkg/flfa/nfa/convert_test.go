@@ -0,0 +1,163 @@
+package nfa
+
+import (
+	"flfa/dfa"
+	"flfa/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Accepts the strings containing 'aa'
+var containsAA = nfa{
+	[]State{"q0", "q1", "q2"},
+	[]Symbol{'a', 'b'},
+	SetDelta{
+		"q0": {
+			'a': {0b011},
+			'b': {0b001},
+		},
+		"q1": {
+			'a': {0b100},
+			'b': {0b000},
+		},
+		"q2": {
+			'a': {0b100},
+			'b': {0b100},
+		},
+	},
+	StateSet{0b001},
+	StateSet{0b100},
+}
+
+func TestNFAToDFA(t *testing.T) {
+	converted, err := containsAA.ToDFA()
+	assert.Equal(t, nil, err)
+
+	want, err := dfa.NewDFA(
+		[]dfa.State{"{q0}", "{q0,q1}", "{q0,q1,q2}", "{q0,q2}"},
+		[]dfa.Symbol{'a', 'b'},
+		dfa.Delta{
+			"{q0}": {
+				'a': "{q0,q1}",
+				'b': "{q0}",
+			},
+			"{q0,q1}": {
+				'a': "{q0,q1,q2}",
+				'b': "{q0}",
+			},
+			"{q0,q1,q2}": {
+				'a': "{q0,q1,q2}",
+				'b': "{q0,q2}",
+			},
+			"{q0,q2}": {
+				'a': "{q0,q1,q2}",
+				'b': "{q0,q2}",
+			},
+		},
+		"{q0}",
+		[]dfa.State{"{q0,q1,q2}", "{q0,q2}"},
+	)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, want, converted)
+
+	for _, str := range testutil.Strings(containsAA.alphabet, 6) {
+		_, want, _ := containsAA.Solve(str)
+		_, got, _ := converted.Solve(str)
+
+		assert.Equal(t, want, got, "string '%v'", str)
+	}
+}
+
+func TestNFAToDFAEmptySubset(t *testing.T) {
+	onlyA := nfa{
+		[]State{"q0", "q1"},
+		[]Symbol{'a', 'b'},
+		SetDelta{
+			"q0": {
+				'a': {0b10},
+				'b': {0b00},
+			},
+			"q1": {
+				'a': {0b00},
+				'b': {0b00},
+			},
+		},
+		StateSet{0b01},
+		StateSet{0b10},
+	}
+
+	converted, err := onlyA.ToDFA()
+	assert.Equal(t, nil, err)
+
+	finalState, isAccepting, err := converted.Solve("ab")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, dfa.State("{}"), finalState)
+	assert.False(t, isAccepting)
+
+	finalState, isAccepting, err = converted.Solve("a")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, dfa.State("{q1}"), finalState)
+	assert.True(t, isAccepting)
+}
+
+func TestEpsilonNFAToDFA(t *testing.T) {
+	converted, err := aStarBStar.ToDFA()
+	assert.Equal(t, nil, err)
+
+	want, err := dfa.NewDFA(
+		[]dfa.State{"{q0,q1}", "{q1}", "{}"},
+		[]dfa.Symbol{'a', 'b'},
+		dfa.Delta{
+			"{q0,q1}": {
+				'a': "{q0,q1}",
+				'b': "{q1}",
+			},
+			"{q1}": {
+				'a': "{}",
+				'b': "{q1}",
+			},
+			"{}": {
+				'a': "{}",
+				'b': "{}",
+			},
+		},
+		"{q0,q1}",
+		[]dfa.State{"{q0,q1}", "{q1}"},
+	)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, want, converted)
+}
+
+func TestNFAToDFAEscapedNames(t *testing.T) {
+	// Without escaping, the subset of 'q0' and 'q1' and the subset of 'q0,q1' would both be named '{q0,q1}'
+	commas := nfa{
+		[]State{"q0", "q1", "q0,q1"},
+		[]Symbol{'a'},
+		SetDelta{
+			"q0": {
+				'a': {0b011},
+			},
+			"q1": {
+				'a': {0b000},
+			},
+			"q0,q1": {
+				'a': {0b011},
+			},
+		},
+		StateSet{0b100},
+		StateSet{0b100},
+	}
+
+	converted, err := commas.ToDFA()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []dfa.State{`{q0\,q1}`, "{q0,q1}"}, converted.States())
+
+	_, isAccepting, err := converted.Solve("")
+	assert.Equal(t, nil, err)
+	assert.True(t, isAccepting)
+
+	_, isAccepting, err = converted.Solve("a")
+	assert.Equal(t, nil, err)
+	assert.False(t, isAccepting)
+}
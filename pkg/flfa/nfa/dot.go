@@ -0,0 +1,60 @@
+package nfa
+
+import (
+	"flfa/internal/dot"
+	"fmt"
+	"strings"
+)
+
+/*
+	Renders the NFA as a Graphviz DOT digraph.
+	Every starting state has an arrow from a point, accepting states are double circles,
+	and the state sets in delta are decoded into one edge per target state.
+	Parallel edges are merged into one edge with a comma separated label in the order of the alphabet, followed by 'ε'.
+	States are numbered in the order of the states array and labelled with their names,
+	so that no state name can clash with the point or with another state.
+	The output only depends on the order of the states and the alphabet, so it can be compared between runs.
+*/
+func (nfa *nfa) ToDOT() string {
+	var builder strings.Builder
+
+	builder.WriteString("digraph {\n")
+	builder.WriteString("\trankdir=LR;\n")
+	builder.WriteString("\tnode [shape=circle];\n")
+	builder.WriteString("\tstart [shape=point];\n")
+
+	for i, state := range nfa.states {
+		if nfa.acceptingStates.Has(i) {
+			fmt.Fprintf(&builder, "\tn%v [label=%v, shape=doublecircle];\n", i, dot.Quote(string(state)))
+		} else {
+			fmt.Fprintf(&builder, "\tn%v [label=%v];\n", i, dot.Quote(string(state)))
+		}
+	}
+
+	for _, i := range nfa.startingStates.Indices() {
+		fmt.Fprintf(&builder, "\tstart -> n%v;\n", i)
+	}
+
+	symbols := append(append([]Symbol(nil), nfa.alphabet...), Epsilon)
+
+	for from, state := range nfa.states {
+		labels := make([][]string, len(nfa.states))
+
+		for _, symbol := range symbols {
+			for _, i := range nfa.delta[state][symbol].Indices() {
+				labels[i] = append(labels[i], string(symbol))
+			}
+		}
+
+		// Edges are written in the order of the states array
+		for i, label := range labels {
+			if len(label) != 0 {
+				fmt.Fprintf(&builder, "\tn%v -> n%v [label=%v];\n", from, i, dot.Quote(strings.Join(label, ",")))
+			}
+		}
+	}
+
+	builder.WriteString("}\n")
+
+	return builder.String()
+}
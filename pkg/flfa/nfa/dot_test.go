@@ -0,0 +1,50 @@
+package nfa
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNFAToDOT(t *testing.T) {
+	golden, err := ioutil.ReadFile("testdata/containsAA.dot")
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, string(golden), containsAA.ToDOT())
+
+	golden, err = ioutil.ReadFile("testdata/aStarBStar.dot")
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, string(golden), aStarBStar.ToDOT())
+}
+
+func TestNFAToDOTReservedNames(t *testing.T) {
+	// States named like the point or like the numbered nodes are only labels
+	n, err := NewNFAFromSets(
+		[]State{"start", "__start", "n0"},
+		[]Symbol{'a'},
+		SetDelta{
+			"start":   {'a': NewStateSet(1)},
+			"__start": {'a': NewStateSet(), Epsilon: NewStateSet(2)},
+			"n0":      {'a': NewStateSet()},
+		},
+		NewStateSet(0, 1),
+		NewStateSet(2),
+	)
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, `digraph {
+	rankdir=LR;
+	node [shape=circle];
+	start [shape=point];
+	n0 [label="start"];
+	n1 [label="__start"];
+	n2 [label="n0", shape=doublecircle];
+	start -> n0;
+	start -> n1;
+	n0 -> n1 [label="a"];
+	n1 -> n2 [label="ε"];
+}
+`, n.ToDOT())
+}
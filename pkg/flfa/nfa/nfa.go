@@ -2,7 +2,6 @@ package nfa
 
 import (
 	"fmt"
-	"strconv"
 )
 
 type args struct {
@@ -12,14 +11,30 @@ type args struct {
 type State string
 type Symbol rune
 type Delta map[State]map[Symbol]StatesBitMap
+type SetDelta map[State]map[Symbol]StateSet
+
+/*
+	A single word bit map of states, for NFAs with at most 64 states.
+*/
 type StatesBitMap uint64
 
+/*
+	The reserved symbol for epsilon moves in delta.
+	Epsilon moves are optional for every state and the symbol cannot be in the alphabet.
+*/
+const Epsilon Symbol = 'ε'
+
+/*
+	The exported name of an NFA, so that other packages can build and accept NFAs.
+*/
+type NFA = nfa
+
 type nfa struct {
 	states          []State
 	alphabet        []Symbol
-	delta           Delta
-	startingStates  StatesBitMap
-	acceptingStates StatesBitMap
+	delta           SetDelta
+	startingStates  StateSet
+	acceptingStates StateSet
 }
 
 /*
@@ -29,9 +44,9 @@ func initializeNFA() nfa {
 	return nfa{
 		[]State([]State(nil)),
 		[]Symbol([]Symbol(nil)),
-		Delta(Delta(nil)),
-		StatesBitMap(0),
-		StatesBitMap(0),
+		SetDelta(SetDelta(nil)),
+		StateSet(StateSet(nil)),
+		StateSet(StateSet(nil)),
 	}
 }
 
@@ -40,11 +55,35 @@ func initializeNFA() nfa {
   If the NFA fails validation, then an empty NFA is returned.
 */
 func NewNFA(states []State, alphabet []Symbol, delta Delta, startingStates StatesBitMap, acceptingStates StatesBitMap) (nfa, error) {
+	setDelta := make(SetDelta, len(delta))
+	for state, transitions := range delta {
+		setDelta[state] = make(map[Symbol]StateSet, len(transitions))
+
+		for symbol, newStates := range transitions {
+			setDelta[state][symbol] = newStates.StateSet()
+		}
+	}
+
+	nfa := nfa{states, alphabet, setDelta, startingStates.StateSet(), acceptingStates.StateSet()}
+
+	err := nfa.validate()
+	if err != nil {
+		return initializeNFA(), err
+	}
+
+	return nfa, nil
+}
+
+/*
+  Creates an NFA of any number of states from state sets and validates it.
+  If the NFA fails validation, then an empty NFA is returned.
+*/
+func NewNFAFromSets(states []State, alphabet []Symbol, delta SetDelta, startingStates StateSet, acceptingStates StateSet) (nfa, error) {
 	nfa := nfa{states, alphabet, delta, startingStates, acceptingStates}
 
 	err := nfa.validate()
 	if err != nil {
-		return initializeNFA(), nil
+		return initializeNFA(), err
 	}
 
 	return nfa, nil
@@ -52,10 +91,26 @@ func NewNFA(states []State, alphabet []Symbol, delta Delta, startingStates State
 
 /*
   Validates and solves an NFA given a string using parallel bit mapping.
+	The epsilon-closure is taken of the starting states and after every symbol.
 	If the NFA fails validation, then an empty NFA is returned.
 	If the given string contains a symbol not in the language, then the current state and false is returned.
+	The final states fit in a single bit map, so NFAs with more than 64 states must be solved with SolveSet.
 */
 func (nfa *nfa) Solve(str string) (StatesBitMap, bool, error) {
+	if len(nfa.states) > wordSize {
+		return 0, false, fmt.Errorf("the nfa has '%v' states, but a states bit map holds at most '%v'", len(nfa.states), wordSize)
+	}
+
+	currentStates, isAccepting, err := nfa.SolveSet(str)
+
+	return StatesBitMap(currentStates.word(0)), isAccepting, err
+}
+
+/*
+	Validates and solves an NFA of any size given a string, returning the final states as a state set.
+	NFAs with at most 64 states are solved on a single word.
+*/
+func (nfa *nfa) SolveSet(str string) (StateSet, bool, error) {
 	currentStates := nfa.startingStates
 
 	err := nfa.validate()
@@ -63,28 +118,143 @@ func (nfa *nfa) Solve(str string) (StatesBitMap, bool, error) {
 		return currentStates, false, err
 	}
 
+	if len(nfa.states) <= wordSize {
+		return nfa.solveWord(str)
+	}
+
+	currentStates = nfa.closure(currentStates)
+
 	for _, symbol := range str {
 		err := nfa.validateSymbol(Symbol(symbol))
 		if err != nil {
 			return currentStates, false, err
 		}
 
-		nextStates := StatesBitMap(0)
+		currentStates = nfa.closure(nfa.move(currentStates, Symbol(symbol)))
+	}
+
+	isAccepting := currentStates.Intersects(nfa.acceptingStates)
+
+	return currentStates, isAccepting, nil
+}
+
+/*
+	Solves an already validated NFA with at most 64 states on a single word.
+*/
+func (nfa *nfa) solveWord(str string) (StateSet, bool, error) {
+	currentStates := nfa.closureWord(nfa.startingStates.word(0))
+
+	for _, symbol := range str {
+		err := nfa.validateSymbol(Symbol(symbol))
+		if err != nil {
+			return StateSet{currentStates}, false, err
+		}
+
+		currentStates = nfa.closureWord(nfa.moveWord(currentStates, Symbol(symbol)))
+	}
+
+	isAccepting := currentStates&nfa.acceptingStates.word(0) != 0
+
+	return StateSet{currentStates}, isAccepting, nil
+}
+
+/*
+	Finds the states reachable from the current states by reading a symbol.
+*/
+func (nfa *nfa) move(currentStates StateSet, symbol Symbol) StateSet {
+	nextStates := make(StateSet, (len(nfa.states)+wordSize-1)/wordSize)
 
-		for i := 0; currentStates != 0; i++ {
-			if currentStates%2 == 1 {
-				nextStates |= nfa.delta[nfa.states[i]][Symbol(symbol)]
+	for w, word := range currentStates {
+		for i := w * wordSize; word != 0; i++ {
+			if word%2 == 1 {
+				nextStates.unionWith(nfa.delta[nfa.states[i]][symbol])
 			}
 
-			currentStates >>= 1
+			word >>= 1
+		}
+	}
+
+	return nextStates
+}
+
+/*
+	Finds the states reachable from the current states by any number of epsilon moves.
+*/
+func (nfa *nfa) closure(currentStates StateSet) StateSet {
+	for {
+		nextStates := currentStates.Union(nfa.move(currentStates, Epsilon))
+		if nextStates.Equal(currentStates) {
+			return currentStates
 		}
 
 		currentStates = nextStates
 	}
+}
 
-	isAccepting := currentStates&nfa.acceptingStates != 0
+/*
+	Finds the states reachable from the current states by reading a symbol on a single word.
+*/
+func (nfa *nfa) moveWord(currentStates uint64, symbol Symbol) uint64 {
+	nextStates := uint64(0)
 
-	return currentStates, isAccepting, nil
+	for i := 0; currentStates != 0; i++ {
+		if currentStates%2 == 1 {
+			nextStates |= nfa.delta[nfa.states[i]][symbol].word(0)
+		}
+
+		currentStates >>= 1
+	}
+
+	return nextStates
+}
+
+/*
+	Finds the states reachable from the current states by any number of epsilon moves on a single word.
+*/
+func (nfa *nfa) closureWord(currentStates uint64) uint64 {
+	for {
+		nextStates := currentStates | nfa.moveWord(currentStates, Epsilon)
+		if nextStates == currentStates {
+			return currentStates
+		}
+
+		currentStates = nextStates
+	}
+}
+
+/*
+	Gets a copy of the NFA's states.
+*/
+func (nfa *nfa) States() []State {
+	return append([]State(nil), nfa.states...)
+}
+
+/*
+	Gets a copy of the NFA's alphabet.
+*/
+func (nfa *nfa) Alphabet() []Symbol {
+	return append([]Symbol(nil), nfa.alphabet...)
+}
+
+/*
+	Gets a copy of the states the NFA moves to from a state by reading a symbol, which may be Epsilon.
+*/
+func (nfa *nfa) Transition(state State, symbol Symbol) StateSet {
+	return StateSet(nil).Union(nfa.delta[state][symbol])
+}
+
+/*
+	Gets a copy of the NFA's starting states.
+*/
+func (nfa *nfa) StartingStates() StateSet {
+	return StateSet(nil).Union(nfa.startingStates)
+}
+
+/*
+	Gets a copy of the NFA's accepting states.
+*/
+func (nfa *nfa) AcceptingStates() StateSet {
+	return StateSet(nil).Union(nfa.acceptingStates)
 }
 
 /*
@@ -118,6 +288,12 @@ func (nfa *nfa) validateDelta() error {
 		return fmt.Errorf("delta contains too many states")
 	}
 
+	for _, symbol := range nfa.alphabet {
+		if symbol == Epsilon {
+			return fmt.Errorf("the epsilon symbol '%v' cannot be within the alphabet", string(Epsilon))
+		}
+	}
+
 	i := 0
 	for _, state := range nfa.states {
 		if nfa.states[i] != state {
@@ -125,8 +301,20 @@ func (nfa *nfa) validateDelta() error {
 		}
 
 		if _, ok := nfa.delta[state]; ok {
+			transitions := len(nfa.delta[state])
+
+			// Epsilon moves are optional, so they are not counted against the alphabet
+			if newState, ok := nfa.delta[state][Epsilon]; ok {
+				err := checkStates(nfa.states, newState, args{str: "new"})
+				if err != nil {
+					return err
+				}
+
+				transitions--
+			}
+
 			// The last error catches if delta has less transitions and pinpoints it
-			if len(nfa.delta[state]) > len(nfa.alphabet) {
+			if transitions > len(nfa.alphabet) {
 				return fmt.Errorf("delta contains too many transitions for the state '%v'", state)
 			}
 		}
@@ -181,12 +369,12 @@ func (nfa *nfa) validateSymbol(symbol Symbol) error {
 }
 
 /*
-	Checks if a state is in a state array.
+	Checks if every state in a state set is in a state array.
 */
-func checkStates(states []State, statesBitMap StatesBitMap, args args) error {
-	if len(states) >= len(strconv.FormatUint(uint64(statesBitMap), 2)) {
+func checkStates(states []State, stateSet StateSet, args args) error {
+	if len(states) >= stateSet.bitLength() {
 		return nil
 	}
 
-	return fmt.Errorf("the %v states bit map '%v' is too long", args.str, statesBitMap)
+	return fmt.Errorf("the %v states bit map '%v' is too long", args.str, stateSet)
 }
@@ -0,0 +1,168 @@
+package nfa
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Accepts a*b* using an epsilon move between the two loops
+var aStarBStar = nfa{
+	[]State{"q0", "q1"},
+	[]Symbol{'a', 'b'},
+	SetDelta{
+		"q0": {
+			'a':     {0b01},
+			'b':     {0b00},
+			Epsilon: {0b10},
+		},
+		"q1": {
+			'a': {0b00},
+			'b': {0b10},
+		},
+	},
+	StateSet{0b01},
+	StateSet{0b10},
+}
+
+type nfaSolveWant struct {
+	str         string
+	finalStates StatesBitMap
+	isAccepting bool
+	err         error
+}
+
+func TestNFASolve(t *testing.T) {
+	var tests = []struct {
+		nfa  nfa
+		want []nfaSolveWant
+	}{
+		{
+			containsAA,
+			[]nfaSolveWant{
+				{"", 0b001, false, nil},
+				{"ab", 0b001, false, nil},
+				{"baa", 0b111, true, nil},
+				{"aab", 0b101, true, nil},
+				{"ac", 0b011, false, fmt.Errorf("the symbol 'c' is not within the alphabet")},
+			},
+		},
+		{
+			aStarBStar,
+			[]nfaSolveWant{
+				{"", 0b11, true, nil},
+				{"aab", 0b10, true, nil},
+				{"abb", 0b10, true, nil},
+				{"ba", 0b00, false, nil},
+				{"ε", 0b11, false, fmt.Errorf("the symbol 'ε' is not within the alphabet")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, want := range tt.want {
+			finalStates, isAccepting, err := tt.nfa.Solve(want.str)
+
+			assert.Equal(t, want.finalStates, finalStates, "string '%v'", want.str)
+			assert.Equal(t, want.isAccepting, isAccepting, "string '%v'", want.str)
+			assert.Equal(t, want.err, err, "string '%v'", want.str)
+
+			finalStateSet, isAccepting, err := tt.nfa.SolveSet(want.str)
+
+			assert.Equal(t, want.finalStates.StateSet(), finalStateSet, "string '%v'", want.str)
+			assert.Equal(t, want.isAccepting, isAccepting, "string '%v'", want.str)
+			assert.Equal(t, want.err, err, "string '%v'", want.str)
+		}
+	}
+}
+
+func TestNFAValidate(t *testing.T) {
+	var tests = []struct {
+		nfa  nfa
+		want error
+	}{
+		{aStarBStar, nil},
+		{
+			nfa{
+				[]State{"q0"},
+				[]Symbol{'a', Epsilon},
+				SetDelta{
+					"q0": {
+						'a':     {0b1},
+						Epsilon: {0b1},
+					},
+				},
+				StateSet{0b1},
+				StateSet{0b1},
+			},
+			fmt.Errorf("the epsilon symbol 'ε' cannot be within the alphabet"),
+		},
+		{
+			nfa{
+				[]State{"q0"},
+				[]Symbol{'a'},
+				SetDelta{
+					"q0": {
+						'a':     {0b1},
+						Epsilon: {0b11},
+					},
+				},
+				StateSet{0b1},
+				StateSet{0b1},
+			},
+			fmt.Errorf("the new states bit map '11' is too long"),
+		},
+		{
+			nfa{
+				[]State{"q0"},
+				[]Symbol{'a'},
+				SetDelta{
+					"q0": {
+						'a':     {0b1},
+						'b':     {0b1},
+						Epsilon: {0b1},
+					},
+				},
+				StateSet{0b1},
+				StateSet{0b1},
+			},
+			fmt.Errorf("delta contains too many transitions for the state 'q0'"),
+		},
+		{
+			nfa{
+				[]State{"q0"},
+				[]Symbol{'a'},
+				SetDelta{
+					"q0": {
+						Epsilon: {0b1},
+					},
+				},
+				StateSet{0b1},
+				StateSet{0b1},
+			},
+			fmt.Errorf("delta is not defined for the state 'q0' and the symbol 'a'"),
+		},
+	}
+
+	for _, tt := range tests {
+		err := tt.nfa.validate()
+
+		assert.Equal(t, tt.want, err)
+	}
+}
+
+func TestNewNFA(t *testing.T) {
+	// Both constructors report the same error for the same invalid NFA
+	want := fmt.Errorf("delta is not defined for the state 'q0' and the symbol 'a'")
+
+	nfa, err := NewNFA([]State{"q0"}, []Symbol{'a'}, Delta{"q0": {}}, 0b1, 0b1)
+
+	assert.Equal(t, want, err)
+	assert.Equal(t, initializeNFA(), nfa)
+
+	nfa, err = NewNFAFromSets([]State{"q0"}, []Symbol{'a'}, SetDelta{"q0": {}}, StateSet{0b1}, StateSet{0b1})
+
+	assert.Equal(t, want, err)
+	assert.Equal(t, initializeNFA(), nfa)
+}
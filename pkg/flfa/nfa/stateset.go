@@ -0,0 +1,181 @@
+package nfa
+
+import (
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+const wordSize = 64
+
+/*
+	A set of states of any size, stored as a bit map split into 64 bit words.
+	The bit i of the word i/64 is set if the state at the index i of the states array is in the set.
+	Missing words are treated as zero, so StateSet{0b101} is the same set as StateSet{0b101, 0}.
+*/
+type StateSet []uint64
+
+/*
+	Creates a state set containing the given state indices.
+*/
+func NewStateSet(indices ...int) StateSet {
+	var set StateSet
+
+	for _, i := range indices {
+		set.Add(i)
+	}
+
+	return set
+}
+
+/*
+	Converts a single word bit map into a state set.
+*/
+func (statesBitMap StatesBitMap) StateSet() StateSet {
+	return StateSet{uint64(statesBitMap)}
+}
+
+/*
+	Adds the state index to the set, growing the set if needed.
+*/
+func (set *StateSet) Add(i int) {
+	for len(*set) <= i/wordSize {
+		*set = append(*set, 0)
+	}
+
+	(*set)[i/wordSize] |= 1 << uint(i%wordSize)
+}
+
+/*
+	Checks if the state index is in the set.
+*/
+func (set StateSet) Has(i int) bool {
+	return set.word(i/wordSize)&(1<<uint(i%wordSize)) != 0
+}
+
+/*
+	Checks if the set has no states.
+*/
+func (set StateSet) IsEmpty() bool {
+	for _, word := range set {
+		if word != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+	Creates the union of two sets.
+*/
+func (set StateSet) Union(other StateSet) StateSet {
+	union := make(StateSet, len(set))
+	copy(union, set)
+	union.unionWith(other)
+
+	return union
+}
+
+/*
+	Checks if two sets share a state.
+*/
+func (set StateSet) Intersects(other StateSet) bool {
+	for i := 0; i < len(set) && i < len(other); i++ {
+		if set[i]&other[i] != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+	Checks if two sets have the same states.
+*/
+func (set StateSet) Equal(other StateSet) bool {
+	for i := 0; i < len(set) || i < len(other); i++ {
+		if set.word(i) != other.word(i) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+	Lists the state indices in the set in increasing order.
+*/
+func (set StateSet) Indices() []int {
+	var indices []int
+
+	for w, word := range set {
+		for word != 0 {
+			indices = append(indices, w*wordSize+bits.TrailingZeros64(word))
+			word &= word - 1
+		}
+	}
+
+	return indices
+}
+
+/*
+	Finds the number of bits needed to hold the set, which is one more than the largest state index.
+*/
+func (set StateSet) bitLength() int {
+	for w := len(set) - 1; w >= 0; w-- {
+		if set[w] != 0 {
+			return w*wordSize + bits.Len64(set[w])
+		}
+	}
+
+	return 0
+}
+
+/*
+	Formats the set as a binary number, the same way '%b' formats a StatesBitMap.
+*/
+func (set StateSet) String() string {
+	length := set.bitLength()
+	if length == 0 {
+		return "0"
+	}
+
+	var builder strings.Builder
+	for w := (length - 1) / wordSize; w >= 0; w-- {
+		word := strconv.FormatUint(set[w], 2)
+
+		// Every word after the first is padded to its full width
+		if builder.Len() != 0 {
+			builder.WriteString(strings.Repeat("0", wordSize-len(word)))
+		}
+
+		builder.WriteString(word)
+	}
+
+	return builder.String()
+}
+
+/*
+	Adds every state of another set to the set, growing the set if needed.
+*/
+func (set *StateSet) unionWith(other StateSet) {
+	for len(*set) < len(other) {
+		*set = append(*set, 0)
+	}
+
+	for i, word := range other {
+		(*set)[i] |= word
+	}
+}
+
+/*
+	Gets a word of the set, treating missing words as zero.
+*/
+func (set StateSet) word(i int) uint64 {
+	if i < len(set) {
+		return set[i]
+	}
+
+	return 0
+}
@@ -0,0 +1,102 @@
+package nfa
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateSet(t *testing.T) {
+	set := NewStateSet(0, 2, 64, 130)
+
+	assert.Equal(t, StateSet{0b101, 0b1, 0b100}, set)
+	assert.Equal(t, []int{0, 2, 64, 130}, set.Indices())
+	assert.True(t, set.Has(64))
+	assert.False(t, set.Has(1))
+	assert.False(t, set.Has(500))
+	assert.False(t, set.IsEmpty())
+	assert.True(t, StateSet{0, 0}.IsEmpty())
+
+	assert.True(t, set.Intersects(NewStateSet(130)))
+	assert.False(t, set.Intersects(NewStateSet(1, 65)))
+	assert.True(t, StateSet{0b11}.Equal(StateSet{0b11, 0}))
+	assert.Equal(t, StateSet{0b111, 0b1, 0b100}, set.Union(StateSet{0b10}))
+
+	assert.Equal(t, "0", StateSet(nil).String())
+	assert.Equal(t, "101", StateSet{0b101}.String())
+	assert.Equal(t, "1"+strings.Repeat("0", 61)+"101", StateSet{0b101, 0b1}.String())
+	assert.Equal(t, "1"+strings.Repeat("0", 127), NewStateSet(127).String())
+}
+
+/*
+	Creates an NFA that moves around a cycle of states on 'a', with an epsilon move skipping half the cycle.
+*/
+func newCycleNFA(t *testing.T, size int) nfa {
+	t.Helper()
+
+	states := make([]State, size)
+	delta := make(SetDelta, size)
+
+	for i := range states {
+		states[i] = State(fmt.Sprintf("q%v", i))
+	}
+
+	for i, state := range states {
+		delta[state] = map[Symbol]StateSet{
+			'a': NewStateSet((i + 1) % size),
+		}
+	}
+	delta["q0"][Epsilon] = NewStateSet(size / 2)
+
+	nfa, err := NewNFAFromSets(states, []Symbol{'a'}, delta, NewStateSet(0), NewStateSet(size-1))
+	require.NoError(t, err)
+
+	return nfa
+}
+
+func TestNFASolveManyStates(t *testing.T) {
+	cycle := newCycleNFA(t, 100)
+
+	for n := 0; n < 250; n++ {
+		finalStates, isAccepting, err := cycle.SolveSet(strings.Repeat("a", n))
+
+		assert.Equal(t, nil, err)
+		assert.True(t, finalStates.Has(n%100), "length %v", n)
+		assert.True(t, finalStates.Has((n+50)%100), "length %v", n)
+		assert.Equal(t, n%50 == 49, isAccepting, "length %v", n)
+	}
+
+	// The final states of a machine this wide do not fit in a bit map
+	_, _, err := cycle.Solve("a")
+	assert.Equal(t, fmt.Errorf("the nfa has '100' states, but a states bit map holds at most '64'"), err)
+
+	converted, err := cycle.ToDFA()
+	assert.Equal(t, nil, err)
+
+	for n := 0; n < 250; n++ {
+		_, isAccepting, err := converted.Solve(strings.Repeat("a", n))
+
+		assert.Equal(t, nil, err)
+		assert.Equal(t, n%50 == 49, isAccepting, "length %v", n)
+	}
+}
+
+func TestNFAFromSetsValidate(t *testing.T) {
+	states := []State{"q0", "q1"}
+	delta := SetDelta{
+		"q0": {
+			'a': NewStateSet(1),
+		},
+		"q1": {
+			'a': NewStateSet(70),
+		},
+	}
+
+	nfa, err := NewNFAFromSets(states, []Symbol{'a'}, delta, NewStateSet(0), NewStateSet(1))
+
+	assert.Equal(t, fmt.Errorf("the new states bit map '%v' is too long", NewStateSet(70)), err)
+	assert.Equal(t, initializeNFA(), nfa)
+}
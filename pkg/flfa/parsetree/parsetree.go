@@ -0,0 +1,126 @@
+package parsetree
+
+import (
+	"flfa/internal/dot"
+	"fmt"
+	"strings"
+)
+
+/*
+	A half-open range [Start, End) of byte offsets in the parsed input.
+*/
+type Span struct {
+	Start int
+	End   int
+}
+
+/*
+	A node of a concrete parse tree.
+	Nonterminal nodes have a child for every symbol of the production they were expanded with,
+	and terminal leaves hold the text they matched.
+*/
+type Node struct {
+	Symbol   string
+	Terminal bool
+	Lexeme   string
+	Span     Span
+	Children []*Node
+}
+
+func (span Span) String() string {
+	return fmt.Sprintf("[%v,%v)", span.Start, span.End)
+}
+
+/*
+	Formats the tree as an indented outline with one node per line.
+	Nonterminals expanded with an empty production have a single 'ε' child line.
+*/
+func (node *Node) String() string {
+	var builder strings.Builder
+
+	node.writeOutline(&builder, 0)
+
+	return builder.String()
+}
+
+func (node *Node) writeOutline(builder *strings.Builder, depth int) {
+	indent := strings.Repeat("  ", depth)
+
+	if node.Terminal {
+		fmt.Fprintf(builder, "%v%v %q %v\n", indent, node.Symbol, node.Lexeme, node.Span)
+		return
+	}
+
+	fmt.Fprintf(builder, "%v%v %v\n", indent, node.Symbol, node.Span)
+
+	if len(node.Children) == 0 {
+		fmt.Fprintf(builder, "%v  ε\n", indent)
+	}
+
+	for _, child := range node.Children {
+		child.writeOutline(builder, depth+1)
+	}
+}
+
+/*
+	Renders the tree as a Graphviz DOT digraph.
+	Nodes are numbered in preorder, so the output can be compared between runs.
+*/
+func (node *Node) ToDOT() string {
+	var builder strings.Builder
+
+	builder.WriteString("digraph {\n")
+	builder.WriteString("\tnode [shape=plaintext];\n")
+
+	next := 0
+	node.writeDOT(&builder, &next)
+
+	builder.WriteString("}\n")
+
+	return builder.String()
+}
+
+/*
+	Writes a node and its descendants, returning the node's number.
+*/
+func (node *Node) writeDOT(builder *strings.Builder, next *int) int {
+	id := *next
+	*next++
+
+	label := node.Symbol
+	if node.Terminal {
+		label = fmt.Sprintf("%v %q", node.Symbol, node.Lexeme)
+		fmt.Fprintf(builder, "\tn%v [label=%v, shape=box];\n", id, dot.Quote(label))
+	} else {
+		fmt.Fprintf(builder, "\tn%v [label=%v];\n", id, dot.Quote(label))
+	}
+
+	if !node.Terminal && len(node.Children) == 0 {
+		epsilon := *next
+		*next++
+
+		fmt.Fprintf(builder, "\tn%v [label=\"ε\"];\n", epsilon)
+		fmt.Fprintf(builder, "\tn%v -> n%v;\n", id, epsilon)
+	}
+
+	for _, child := range node.Children {
+		childID := child.writeDOT(builder, next)
+		fmt.Fprintf(builder, "\tn%v -> n%v;\n", id, childID)
+	}
+
+	return id
+}
+
+/*
+	Sets the span of every nonterminal to cover its children.
+	Nonterminals without children keep their span.
+*/
+func (node *Node) FitSpans() {
+	for _, child := range node.Children {
+		child.FitSpans()
+	}
+
+	if len(node.Children) != 0 {
+		node.Span = Span{node.Children[0].Span.Start, node.Children[len(node.Children)-1].Span.End}
+	}
+}
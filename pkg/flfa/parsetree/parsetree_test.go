@@ -0,0 +1,58 @@
+package parsetree
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+	The tree of "(a)" for S → ( S ) | a T, T → ε.
+*/
+func newTree() *Node {
+	return &Node{
+		Symbol: "S",
+		Children: []*Node{
+			{Symbol: "(", Terminal: true, Lexeme: "(", Span: Span{0, 1}},
+			{
+				Symbol: "S",
+				Children: []*Node{
+					{Symbol: "a", Terminal: true, Lexeme: "a", Span: Span{1, 2}},
+					{Symbol: "T", Span: Span{2, 2}},
+				},
+			},
+			{Symbol: ")", Terminal: true, Lexeme: ")", Span: Span{2, 3}},
+		},
+	}
+}
+
+func TestFitSpans(t *testing.T) {
+	tree := newTree()
+	tree.FitSpans()
+
+	assert.Equal(t, Span{0, 3}, tree.Span)
+	assert.Equal(t, Span{1, 2}, tree.Children[1].Span)
+	assert.Equal(t, Span{2, 2}, tree.Children[1].Children[1].Span)
+}
+
+func TestString(t *testing.T) {
+	tree := newTree()
+	tree.FitSpans()
+
+	assert.Equal(t, `S [0,3)
+  ( "(" [0,1)
+  S [1,2)
+    a "a" [1,2)
+    T [2,2)
+      ε
+  ) ")" [2,3)
+`, tree.String())
+}
+
+func TestToDOT(t *testing.T) {
+	golden, err := ioutil.ReadFile("testdata/tree.dot")
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, string(golden), newTree().ToDOT())
+}
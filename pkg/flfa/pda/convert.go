@@ -0,0 +1,243 @@
+package pda
+
+import (
+	"flfa/grammar"
+	"flfa/internal/sliceutil"
+	"fmt"
+)
+
+/*
+	Converts a grammar into a PDA of a single state 'q' that accepts its language by empty stack.
+	The stack starts with the start symbol, a nonterminal on top of the stack is replaced with the body of one of its productions,
+	and a terminal on top of the stack is popped when it is read.
+*/
+func FromGrammar(g grammar.Grammar) (PDA, error) {
+	var inputAlphabet []Symbol
+	var stackAlphabet []Symbol
+	var transitions []Transition
+
+	for _, nonterminal := range g.Nonterminals() {
+		stackAlphabet = append(stackAlphabet, Symbol(nonterminal))
+	}
+
+	for _, production := range g.Productions() {
+		transitions = append(transitions, Transition{"q", Epsilon, Symbol(production.Head), "q", toSymbols(production.Body)})
+	}
+
+	for _, terminal := range g.Terminals() {
+		inputAlphabet = append(inputAlphabet, Symbol(terminal))
+		stackAlphabet = append(stackAlphabet, Symbol(terminal))
+		transitions = append(transitions, Transition{"q", Symbol(terminal), Symbol(terminal), "q", nil})
+	}
+
+	return NewPDA([]State{"q"}, inputAlphabet, stackAlphabet, transitions, "q", Symbol(g.Start()), nil, EmptyStack)
+}
+
+/*
+	Converts a PDA into a grammar of its language.
+	The PDA is first made to accept by empty stack with every move popping a symbol,
+	and the grammar then has a nonterminal '[p,X,q]' deriving the inputs that take the PDA from p to q while popping X.
+	Useless nonterminals are removed, so a PDA that accepts nothing gives a grammar with only a start symbol 'S'.
+*/
+func ToGrammar(pda PDA) (grammar.Grammar, error) {
+	err := pda.validate()
+	if err != nil {
+		return grammar.Grammar{}, err
+	}
+
+	normalized := pda.normalize()
+
+	var terminals []grammar.Symbol
+	for _, symbol := range normalized.inputAlphabet {
+		terminals = append(terminals, grammar.Symbol(symbol))
+	}
+
+	start := grammar.Symbol("S")
+	for sliceutil.Contains(terminals, start) {
+		start += "'"
+	}
+
+	nonterminals := []grammar.Symbol{start}
+	var productions []grammar.Production
+
+	triple := func(from State, symbol Symbol, to State) grammar.Symbol {
+		nonterminal := grammar.Symbol(fmt.Sprintf("[%v,%v,%v]", from, symbol, to))
+		if !sliceutil.Contains(nonterminals, nonterminal) {
+			nonterminals = append(nonterminals, nonterminal)
+		}
+
+		return nonterminal
+	}
+
+	for _, state := range normalized.states {
+		body := []grammar.Symbol{triple(normalized.startingState, normalized.startingStack, state)}
+		productions = append(productions, grammar.Production{Head: start, Body: body})
+	}
+
+	for _, transition := range normalized.transitions {
+		var read []grammar.Symbol
+		if transition.Input != Epsilon {
+			read = []grammar.Symbol{grammar.Symbol(transition.Input)}
+		}
+
+		// Every sequence of states the pushed symbols may be popped in, the last one being the state after the pop
+		for _, states := range sequences(normalized.states, len(transition.Push)) {
+			to := transition.To
+			if len(states) != 0 {
+				to = states[len(states)-1]
+			}
+
+			body := append([]grammar.Symbol(nil), read...)
+			from := transition.To
+			for i, symbol := range transition.Push {
+				body = append(body, triple(from, symbol, states[i]))
+				from = states[i]
+			}
+
+			productions = append(productions, grammar.Production{Head: triple(transition.From, transition.Pop, to), Body: body})
+		}
+	}
+
+	g, err := grammar.NewGrammar(nonterminals, terminals, start, productions)
+	if err != nil {
+		return grammar.Grammar{}, err
+	}
+
+	useful, err := g.RemoveUseless()
+	if err != nil {
+		// The start symbol derives no string, so the language is empty
+		return grammar.NewGrammar([]grammar.Symbol{start}, terminals, start, nil)
+	}
+
+	return useful, nil
+}
+
+/*
+	Creates an equivalent PDA that accepts by empty stack, where every move pops a symbol.
+	A new bottom symbol is kept under the stack, so that moves that pop nothing can pop any symbol and push it back,
+	and the bottom symbol is only popped once the PDA accepts.
+	A PDA that accepts by final state moves from its accepting states to a new state that empties the stack.
+*/
+func (pda *PDA) normalize() PDA {
+	bottom := freshSymbol("⊥", pda.stackAlphabet)
+	start := freshState("s", pda.states)
+	drain := freshState("d", append(pda.States(), start))
+
+	states := append([]State{start}, pda.states...)
+	stackAlphabet := append(pda.StackAlphabet(), bottom)
+
+	push := []Symbol{bottom}
+	if pda.startingStack != Epsilon {
+		push = []Symbol{pda.startingStack, bottom}
+	}
+
+	transitions := []Transition{{start, Epsilon, bottom, pda.startingState, push}}
+
+	for _, transition := range pda.transitions {
+		if transition.Pop != Epsilon {
+			transitions = append(transitions, transition)
+			continue
+		}
+
+		for _, symbol := range stackAlphabet {
+			push := append(append([]Symbol(nil), transition.Push...), symbol)
+			transitions = append(transitions, Transition{transition.From, transition.Input, symbol, transition.To, push})
+		}
+	}
+
+	if pda.acceptance == EmptyStack {
+		for _, state := range pda.states {
+			transitions = append(transitions, Transition{state, Epsilon, bottom, state, nil})
+		}
+	} else {
+		states = append(states, drain)
+
+		for _, symbol := range stackAlphabet {
+			for _, state := range pda.acceptingStates {
+				transitions = append(transitions, Transition{state, Epsilon, symbol, drain, nil})
+			}
+
+			transitions = append(transitions, Transition{drain, Epsilon, symbol, drain, nil})
+		}
+	}
+
+	return PDA{states, pda.InputAlphabet(), stackAlphabet, transitions, start, bottom, nil, EmptyStack}
+}
+
+/*
+	Lists every sequence of the given length of states.
+*/
+func sequences(states []State, length int) [][]State {
+	if length == 0 {
+		return [][]State{{}}
+	}
+
+	var longer [][]State
+	for _, sequence := range sequences(states, length-1) {
+		for _, state := range states {
+			longer = append(longer, append(append([]State(nil), sequence...), state))
+		}
+	}
+
+	return longer
+}
+
+/*
+	Creates a stack symbol with primes added until it is not one of the given symbols.
+*/
+func freshSymbol(symbol Symbol, symbols []Symbol) Symbol {
+	for containsStackSymbol(symbols, symbol) {
+		symbol += "'"
+	}
+
+	return symbol
+}
+
+/*
+	Creates a state with primes added until it is not one of the given states.
+*/
+func freshState(state State, states []State) State {
+	for containsState(states, state) {
+		state += "'"
+	}
+
+	return state
+}
+
+/*
+	Converts grammar symbols into stack symbols.
+*/
+func toSymbols(symbols []grammar.Symbol) []Symbol {
+	converted := make([]Symbol, len(symbols))
+	for i, symbol := range symbols {
+		converted[i] = Symbol(symbol)
+	}
+
+	return converted
+}
+
+/*
+	Checks if a symbol is in a symbol array.
+*/
+func containsStackSymbol(symbols []Symbol, symbol Symbol) bool {
+	for _, possibleSymbol := range symbols {
+		if possibleSymbol == symbol {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+	Checks if a state is in a state array.
+*/
+func containsState(states []State, state State) bool {
+	for _, possibleState := range states {
+		if possibleState == state {
+			return true
+		}
+	}
+
+	return false
+}
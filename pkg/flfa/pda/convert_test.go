@@ -0,0 +1,119 @@
+package pda
+
+import (
+	"flfa/earley"
+	"flfa/grammar"
+	"flfa/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+	Asserts that a PDA and a grammar accept the same strings of up to the given length.
+*/
+func assertSameLanguage(t *testing.T, pda PDA, g grammar.Grammar, length int) {
+	parser := earley.NewParser(g, nil)
+	accepted := 0
+
+	for _, str := range testutil.Strings(pda.InputAlphabet(), length) {
+		result, err := pda.Solve(Symbols(str), 50)
+		assert.Equal(t, nil, err, "string '%v'", str)
+
+		isAccepted, err := parser.Recognize(str)
+		assert.Equal(t, nil, err, "string '%v'", str)
+		assert.Equal(t, isAccepted, result.IsAccepting, "string '%v'", str)
+
+		if isAccepted {
+			accepted++
+		}
+	}
+
+	assert.NotEqual(t, 0, accepted)
+}
+
+func TestFromGrammar(t *testing.T) {
+	g, err := grammar.NewGrammar(
+		[]grammar.Symbol{"S"},
+		[]grammar.Symbol{"(", ")", "[", "]"},
+		"S",
+		[]grammar.Production{
+			{Head: "S", Body: []grammar.Symbol{"(", "S", ")", "S"}},
+			{Head: "S", Body: []grammar.Symbol{"[", "S", "]", "S"}},
+			{Head: "S", Body: []grammar.Symbol{}},
+		},
+	)
+	assert.Equal(t, nil, err)
+
+	pda, err := FromGrammar(g)
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, []State{"q"}, pda.States())
+	assert.Equal(t, EmptyStack, pda.Acceptance())
+	assert.Equal(t, Symbol("S"), pda.StartingStack())
+	assert.Equal(t, []Symbol{"S", "(", ")", "[", "]"}, pda.StackAlphabet())
+	assert.Equal(t, Transition{"q", Epsilon, "S", "q", []Symbol{"[", "S", "]", "S"}}, pda.Transitions()[1])
+	assert.Equal(t, Transition{"q", "(", "(", "q", nil}, pda.Transitions()[3])
+
+	assertSameLanguage(t, pda, g, 6)
+}
+
+func TestToGrammar(t *testing.T) {
+	g, err := ToGrammar(newEqualCountPDA(t))
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `S -> [s,⊥,d]
+[s,⊥,d] -> [q0,Z,d] [d,⊥,d]
+[q0,Z,d] -> a [q0,A,q1] [q1,Z,d]
+[q0,A,q1] -> a [q0,A,q1] [q1,A,q1]
+[q0,Z,d] -> [q1,Z,d]
+[q0,A,q1] -> [q1,A,q1]
+[q1,A,q1] -> b
+[q1,Z,d] -> [q2,Z,d]
+[q2,Z,d] -> ε
+[d,⊥,d] -> ε
+`, g.String())
+
+	for _, pda := range []PDA{newEqualCountPDA(t), newPalindromePDA(t)} {
+		g, err := ToGrammar(pda)
+		assert.Equal(t, nil, err)
+
+		assertSameLanguage(t, pda, g, 8)
+	}
+}
+
+func TestToGrammarEmpty(t *testing.T) {
+	// The accepting state cannot be reached
+	pda, err := NewPDA([]State{"p", "q"}, []Symbol{"a"}, []Symbol{"Z"}, []Transition{{"p", "a", "Z", "p", []Symbol{"Z"}}}, "p", "Z", []State{"q"}, FinalState)
+	assert.Equal(t, nil, err)
+
+	g, err := ToGrammar(pda)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []grammar.Symbol{"S"}, g.Nonterminals())
+	assert.Equal(t, []grammar.Symbol{"a"}, g.Terminals())
+	assert.Nil(t, g.Productions())
+}
+
+func TestRoundTrip(t *testing.T) {
+	g, err := grammar.NewGrammar(
+		[]grammar.Symbol{"E", "T"},
+		[]grammar.Symbol{"+", "x", "(", ")"},
+		"E",
+		[]grammar.Production{
+			{Head: "E", Body: []grammar.Symbol{"T", "+", "E"}},
+			{Head: "E", Body: []grammar.Symbol{"T"}},
+			{Head: "T", Body: []grammar.Symbol{"(", "E", ")"}},
+			{Head: "T", Body: []grammar.Symbol{"x"}},
+		},
+	)
+	assert.Equal(t, nil, err)
+
+	pda, err := FromGrammar(g)
+	assert.Equal(t, nil, err)
+
+	converted, err := ToGrammar(pda)
+	assert.Equal(t, nil, err)
+
+	assertSameLanguage(t, pda, converted, 5)
+	assertSameLanguage(t, pda, g, 5)
+}
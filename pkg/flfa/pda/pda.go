@@ -0,0 +1,271 @@
+package pda
+
+import (
+	"fmt"
+	"strings"
+)
+
+type State string
+type Symbol string
+
+/*
+	The reserved symbol for moves that read no input or pop no stack symbol.
+	The symbol cannot be in the input or stack alphabets.
+*/
+const Epsilon Symbol = "ε"
+
+/*
+	The ways a pushdown automaton accepts a string once all of it is read.
+*/
+type Acceptance int
+
+const (
+	// The automaton is in an accepting state.
+	FinalState Acceptance = iota
+	// The stack is empty.
+	EmptyStack
+)
+
+/*
+	A move from a state to another that reads an input symbol and pops a stack symbol, either of which may be Epsilon,
+	and pushes a string of stack symbols, where the first one ends up on the top of the stack.
+*/
+type Transition struct {
+	From  State
+	Input Symbol
+	Pop   Symbol
+	To    State
+	Push  []Symbol
+}
+
+/*
+	A nondeterministic pushdown automaton.
+*/
+type PDA struct {
+	states          []State
+	inputAlphabet   []Symbol
+	stackAlphabet   []Symbol
+	transitions     []Transition
+	startingState   State
+	startingStack   Symbol
+	acceptingStates []State
+	acceptance      Acceptance
+}
+
+/*
+	Creates a PDA and validates it.
+	The stack starts with the starting stack symbol, or empty if it is Epsilon.
+	If the PDA fails validation, then an empty PDA is returned.
+*/
+func NewPDA(states []State, inputAlphabet []Symbol, stackAlphabet []Symbol, transitions []Transition, startingState State, startingStack Symbol, acceptingStates []State, acceptance Acceptance) (PDA, error) {
+	pda := PDA{states, inputAlphabet, stackAlphabet, transitions, startingState, startingStack, acceptingStates, acceptance}
+
+	err := pda.validate()
+	if err != nil {
+		return PDA{}, err
+	}
+
+	return pda, nil
+}
+
+/*
+	Gets a copy of the PDA's states.
+*/
+func (pda *PDA) States() []State {
+	return append([]State(nil), pda.states...)
+}
+
+/*
+	Gets a copy of the PDA's input alphabet.
+*/
+func (pda *PDA) InputAlphabet() []Symbol {
+	return append([]Symbol(nil), pda.inputAlphabet...)
+}
+
+/*
+	Gets a copy of the PDA's stack alphabet.
+*/
+func (pda *PDA) StackAlphabet() []Symbol {
+	return append([]Symbol(nil), pda.stackAlphabet...)
+}
+
+/*
+	Gets a copy of the PDA's transitions.
+*/
+func (pda *PDA) Transitions() []Transition {
+	return append([]Transition(nil), pda.transitions...)
+}
+
+/*
+	Gets the PDA's starting state.
+*/
+func (pda *PDA) StartingState() State {
+	return pda.startingState
+}
+
+/*
+	Gets the PDA's starting stack symbol.
+*/
+func (pda *PDA) StartingStack() Symbol {
+	return pda.startingStack
+}
+
+/*
+	Gets a copy of the PDA's accepting states.
+*/
+func (pda *PDA) AcceptingStates() []State {
+	return append([]State(nil), pda.acceptingStates...)
+}
+
+/*
+	Gets the way the PDA accepts strings.
+*/
+func (pda *PDA) Acceptance() Acceptance {
+	return pda.acceptance
+}
+
+/*
+	Splits a string into one input symbol per character.
+*/
+func Symbols(str string) []Symbol {
+	var symbols []Symbol
+	for _, char := range str {
+		symbols = append(symbols, Symbol(char))
+	}
+
+	return symbols
+}
+
+/*
+	Formats the transition as 'p --a, X/Y Z--> q', where Y is pushed on top of Z.
+*/
+func (transition Transition) String() string {
+	push := string(Epsilon)
+	if len(transition.Push) != 0 {
+		symbols := make([]string, len(transition.Push))
+		for i, symbol := range transition.Push {
+			symbols[i] = string(symbol)
+		}
+
+		push = strings.Join(symbols, " ")
+	}
+
+	return fmt.Sprintf("%v --%v, %v/%v--> %v", transition.From, transition.Input, transition.Pop, push, transition.To)
+}
+
+/*
+	Validates the PDA.
+*/
+func (pda *PDA) validate() error {
+	for _, symbol := range append(pda.InputAlphabet(), pda.stackAlphabet...) {
+		if symbol == Epsilon {
+			return fmt.Errorf("the symbol '%v' is reserved for epsilon moves", Epsilon)
+		}
+	}
+
+	err := pda.validateState(pda.startingState)
+	if err != nil {
+		return err
+	}
+
+	if pda.startingStack != Epsilon {
+		err = pda.validateStackSymbol(pda.startingStack)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, state := range pda.acceptingStates {
+		err = pda.validateState(state)
+		if err != nil {
+			return err
+		}
+	}
+
+	if pda.acceptance != FinalState && pda.acceptance != EmptyStack {
+		return fmt.Errorf("the acceptance '%v' must be either final state or empty stack", pda.acceptance)
+	}
+
+	for _, transition := range pda.transitions {
+		err = pda.validateTransition(transition)
+		if err != nil {
+			return fmt.Errorf("the transition '%v' is invalid: %v", transition, err)
+		}
+	}
+
+	return nil
+}
+
+/*
+	Validates the states and symbols of a transition.
+*/
+func (pda *PDA) validateTransition(transition Transition) error {
+	for _, state := range []State{transition.From, transition.To} {
+		err := pda.validateState(state)
+		if err != nil {
+			return err
+		}
+	}
+
+	if transition.Input != Epsilon {
+		err := pda.validateInputSymbol(transition.Input)
+		if err != nil {
+			return err
+		}
+	}
+
+	if transition.Pop != Epsilon {
+		err := pda.validateStackSymbol(transition.Pop)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, symbol := range transition.Push {
+		err := pda.validateStackSymbol(symbol)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/*
+	Validates a given state against the PDA's states.
+*/
+func (pda *PDA) validateState(state State) error {
+	for _, possibleState := range pda.states {
+		if state == possibleState {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("the state '%v' is not in the states", state)
+}
+
+/*
+	Validates a given symbol against the PDA's input alphabet.
+*/
+func (pda *PDA) validateInputSymbol(symbol Symbol) error {
+	for _, possibleSymbol := range pda.inputAlphabet {
+		if symbol == possibleSymbol {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("the symbol '%v' is not in the input alphabet", symbol)
+}
+
+/*
+	Validates a given symbol against the PDA's stack alphabet.
+*/
+func (pda *PDA) validateStackSymbol(symbol Symbol) error {
+	for _, possibleSymbol := range pda.stackAlphabet {
+		if symbol == possibleSymbol {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("the symbol '%v' is not in the stack alphabet", symbol)
+}
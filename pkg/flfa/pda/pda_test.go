@@ -0,0 +1,95 @@
+package pda
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/*
+	Creates a PDA that accepts the strings a^n b^n by final state.
+*/
+func newEqualCountPDA(t *testing.T) PDA {
+	t.Helper()
+
+	pda, err := NewPDA(
+		[]State{"q0", "q1", "q2"},
+		[]Symbol{"a", "b"},
+		[]Symbol{"Z", "A"},
+		[]Transition{
+			{"q0", "a", Epsilon, "q0", []Symbol{"A"}},
+			{"q0", Epsilon, Epsilon, "q1", nil},
+			{"q1", "b", "A", "q1", nil},
+			{"q1", Epsilon, "Z", "q2", []Symbol{"Z"}},
+		},
+		"q0",
+		"Z",
+		[]State{"q2"},
+		FinalState,
+	)
+	require.NoError(t, err)
+
+	return pda
+}
+
+/*
+	Creates a PDA that accepts the palindromes of even length by empty stack.
+*/
+func newPalindromePDA(t *testing.T) PDA {
+	t.Helper()
+
+	pda, err := NewPDA(
+		[]State{"push", "pop"},
+		[]Symbol{"a", "b"},
+		[]Symbol{"Z", "a", "b"},
+		[]Transition{
+			{"push", "a", Epsilon, "push", []Symbol{"a"}},
+			{"push", "b", Epsilon, "push", []Symbol{"b"}},
+			{"push", Epsilon, Epsilon, "pop", nil},
+			{"pop", "a", "a", "pop", nil},
+			{"pop", "b", "b", "pop", nil},
+			{"pop", Epsilon, "Z", "pop", nil},
+		},
+		"push",
+		"Z",
+		nil,
+		EmptyStack,
+	)
+	require.NoError(t, err)
+
+	return pda
+}
+
+func TestNewPDA(t *testing.T) {
+	var tests = []struct {
+		stackAlphabet []Symbol
+		transitions   []Transition
+		startingState State
+		startingStack Symbol
+		acceptance    Acceptance
+		want          error
+	}{
+		{[]Symbol{"Z"}, []Transition{{"p", "a", "Z", "q", []Symbol{"Z", "Z"}}}, "p", "Z", FinalState, nil},
+		{[]Symbol{"Z"}, nil, "p", Epsilon, EmptyStack, nil},
+		{[]Symbol{"Z", Epsilon}, nil, "p", "Z", FinalState, fmt.Errorf("the symbol 'ε' is reserved for epsilon moves")},
+		{[]Symbol{"Z"}, nil, "r", "Z", FinalState, fmt.Errorf("the state 'r' is not in the states")},
+		{[]Symbol{"Z"}, nil, "p", "Y", FinalState, fmt.Errorf("the symbol 'Y' is not in the stack alphabet")},
+		{[]Symbol{"Z"}, nil, "p", "Z", Acceptance(2), fmt.Errorf("the acceptance '2' must be either final state or empty stack")},
+		{[]Symbol{"Z"}, []Transition{{"p", "b", "Z", "q", nil}}, "p", "Z", FinalState, fmt.Errorf("the transition 'p --b, Z/ε--> q' is invalid: the symbol 'b' is not in the input alphabet")},
+		{[]Symbol{"Z"}, []Transition{{"p", "a", Epsilon, "q", []Symbol{"Z", "Y"}}}, "p", "Z", FinalState, fmt.Errorf("the transition 'p --a, ε/Z Y--> q' is invalid: the symbol 'Y' is not in the stack alphabet")},
+		{[]Symbol{"Z"}, []Transition{{"p", "a", "Z", "r", nil}}, "p", "Z", FinalState, fmt.Errorf("the transition 'p --a, Z/ε--> r' is invalid: the state 'r' is not in the states")},
+	}
+
+	for _, tt := range tests {
+		_, err := NewPDA([]State{"p", "q"}, []Symbol{"a"}, tt.stackAlphabet, tt.transitions, tt.startingState, tt.startingStack, []State{"q"}, tt.acceptance)
+
+		assert.Equal(t, tt.want, err)
+	}
+}
+
+func TestSymbols(t *testing.T) {
+	assert.Equal(t, []Symbol{"a", "ß", "b"}, Symbols("aßb"))
+	assert.Nil(t, Symbols(""))
+}
@@ -0,0 +1,193 @@
+package pda
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+	A configuration of a PDA, its state, how many input symbols it has read, and its stack with the top first.
+*/
+type Configuration struct {
+	State    State
+	Position int
+	Stack    []Symbol
+}
+
+/*
+	The result of simulating a PDA on an input.
+	If the input is accepted, then the configurations from the starting one to an accepting one are given.
+	If a computation was cut off by the step bound before it ended, then a rejection is not final,
+	since a longer computation could still have accepted the input.
+*/
+type Result struct {
+	IsAccepting    bool
+	IsBoundReached bool
+	Path           []Configuration
+}
+
+/*
+	Validates and solves a PDA given an input, exploring its configurations breadth-first.
+	A configuration is explored once, and only computations of at most the step bound moves are followed,
+	so that the simulation ends even when epsilon moves can push forever.
+*/
+func (pda *PDA) Solve(input []Symbol, bound int) (Result, error) {
+	err := pda.validate()
+	if err != nil {
+		return Result{}, err
+	}
+
+	if bound < 0 {
+		return Result{}, fmt.Errorf("the step bound '%v' must not be negative", bound)
+	}
+
+	for _, symbol := range input {
+		err = pda.validateInputSymbol(symbol)
+		if err != nil {
+			return Result{}, err
+		}
+	}
+
+	start := Configuration{pda.startingState, 0, nil}
+	if pda.startingStack != Epsilon {
+		start.Stack = []Symbol{pda.startingStack}
+	}
+
+	// Every configuration found and the one it was first reached from, so that the accepting path can be rebuilt
+	isVisited := map[string]bool{start.key(): true}
+	configurations := []Configuration{start}
+	parentOf := []int{-1}
+
+	isBoundReached := false
+	frontier := []int{0}
+
+	for steps := 0; len(frontier) != 0; steps++ {
+		var next []int
+
+		for _, i := range frontier {
+			configuration := configurations[i]
+
+			if pda.isAccepting(configuration, len(input)) {
+				return Result{true, false, path(configurations, parentOf, i)}, nil
+			}
+
+			successors := pda.successors(configuration, input)
+			if steps == bound {
+				if len(successors) != 0 {
+					isBoundReached = true
+				}
+
+				continue
+			}
+
+			for _, successor := range successors {
+				key := successor.key()
+				if isVisited[key] {
+					continue
+				}
+
+				isVisited[key] = true
+				configurations = append(configurations, successor)
+				parentOf = append(parentOf, i)
+				next = append(next, len(configurations)-1)
+			}
+		}
+
+		frontier = next
+	}
+
+	return Result{false, isBoundReached, nil}, nil
+}
+
+/*
+	Checks if a configuration accepts an input of the given length.
+*/
+func (pda *PDA) isAccepting(configuration Configuration, length int) bool {
+	if configuration.Position != length {
+		return false
+	}
+
+	if pda.acceptance == EmptyStack {
+		return len(configuration.Stack) == 0
+	}
+
+	for _, state := range pda.acceptingStates {
+		if configuration.State == state {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+	Finds the configurations a configuration moves to in one step, in the order of the transitions.
+*/
+func (pda *PDA) successors(configuration Configuration, input []Symbol) []Configuration {
+	var successors []Configuration
+
+	for _, transition := range pda.transitions {
+		if transition.From != configuration.State {
+			continue
+		}
+
+		position := configuration.Position
+		if transition.Input != Epsilon {
+			if position == len(input) || input[position] != transition.Input {
+				continue
+			}
+
+			position++
+		}
+
+		stack := configuration.Stack
+		if transition.Pop != Epsilon {
+			if len(stack) == 0 || stack[0] != transition.Pop {
+				continue
+			}
+
+			stack = stack[1:]
+		}
+
+		stack = append(append([]Symbol(nil), transition.Push...), stack...)
+		successors = append(successors, Configuration{transition.To, position, stack})
+	}
+
+	return successors
+}
+
+/*
+	Rebuilds the configurations from the starting one to the given one.
+*/
+func path(configurations []Configuration, parentOf []int, i int) []Configuration {
+	var path []Configuration
+	for ; i != -1; i = parentOf[i] {
+		path = append([]Configuration{configurations[i]}, path...)
+	}
+
+	return path
+}
+
+/*
+	Formats the configuration as '(q, 2, X Y)', where X is the top of the stack and 'ε' is the empty stack.
+*/
+func (configuration Configuration) String() string {
+	stack := string(Epsilon)
+	if len(configuration.Stack) != 0 {
+		symbols := make([]string, len(configuration.Stack))
+		for i, symbol := range configuration.Stack {
+			symbols[i] = string(symbol)
+		}
+
+		stack = strings.Join(symbols, " ")
+	}
+
+	return fmt.Sprintf("(%v, %v, %v)", configuration.State, configuration.Position, stack)
+}
+
+/*
+	Gets a key that identifies the configuration.
+*/
+func (configuration Configuration) key() string {
+	return fmt.Sprintf("%q %v %q", configuration.State, configuration.Position, configuration.Stack)
+}
@@ -0,0 +1,97 @@
+package pda
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolve(t *testing.T) {
+	var tests = []struct {
+		pda  PDA
+		str  string
+		want bool
+	}{
+		{newEqualCountPDA(t), "", true},
+		{newEqualCountPDA(t), "ab", true},
+		{newEqualCountPDA(t), "aaabbb", true},
+		{newEqualCountPDA(t), "aabbb", false},
+		{newEqualCountPDA(t), "aab", false},
+		{newEqualCountPDA(t), "abab", false},
+		{newPalindromePDA(t), "", true},
+		{newPalindromePDA(t), "abba", true},
+		{newPalindromePDA(t), "babbab", true},
+		{newPalindromePDA(t), "aba", false},
+		{newPalindromePDA(t), "abab", false},
+	}
+
+	for _, tt := range tests {
+		result, err := tt.pda.Solve(Symbols(tt.str), 100)
+
+		assert.Equal(t, nil, err, "string '%v'", tt.str)
+		assert.Equal(t, tt.want, result.IsAccepting, "string '%v'", tt.str)
+		assert.False(t, result.IsBoundReached, "string '%v'", tt.str)
+	}
+}
+
+func TestSolvePath(t *testing.T) {
+	pda := newPalindromePDA(t)
+
+	result, err := pda.Solve(Symbols("abba"), 100)
+	assert.Equal(t, nil, err)
+
+	var path []string
+	for _, configuration := range result.Path {
+		path = append(path, configuration.String())
+	}
+
+	assert.Equal(t, []string{
+		"(push, 0, Z)",
+		"(push, 1, a Z)",
+		"(push, 2, b a Z)",
+		"(pop, 2, b a Z)",
+		"(pop, 3, a Z)",
+		"(pop, 4, Z)",
+		"(pop, 4, ε)",
+	}, path)
+}
+
+func TestSolveBound(t *testing.T) {
+	// The epsilon move pushes forever, so only the bound stops the search
+	pda, err := NewPDA(
+		[]State{"p", "q"},
+		[]Symbol{"a"},
+		[]Symbol{"X"},
+		[]Transition{
+			{"p", Epsilon, Epsilon, "p", []Symbol{"X"}},
+			{"p", "a", "X", "q", nil},
+		},
+		"p",
+		Epsilon,
+		[]State{"q"},
+		FinalState,
+	)
+	assert.Equal(t, nil, err)
+
+	result, err := pda.Solve(Symbols("a"), 10)
+	assert.Equal(t, nil, err)
+	assert.True(t, result.IsAccepting)
+	assert.Equal(t, 3, len(result.Path))
+
+	result, err = pda.Solve(Symbols("aa"), 10)
+	assert.Equal(t, nil, err)
+	assert.False(t, result.IsAccepting)
+	assert.True(t, result.IsBoundReached)
+
+	result, err = pda.Solve(Symbols("a"), 1)
+	assert.Equal(t, nil, err)
+	assert.False(t, result.IsAccepting)
+	assert.True(t, result.IsBoundReached)
+
+	_, err = pda.Solve(Symbols("a"), -1)
+	assert.Equal(t, fmt.Errorf("the step bound '-1' must not be negative"), err)
+
+	_, err = pda.Solve(Symbols("ab"), 10)
+	assert.Equal(t, fmt.Errorf("the symbol 'b' is not in the input alphabet"), err)
+}
@@ -0,0 +1,86 @@
+package regex
+
+import (
+	"flfa/nfa"
+)
+
+/*
+	A node of a regular expression's syntax tree.
+*/
+type Node interface {
+	node()
+
+	// Formats the node as a pattern that parses back into the same language
+	String() string
+}
+
+/*
+	Matches no strings, written '∅'.
+*/
+type EmptySet struct{}
+
+/*
+	Matches only the empty string, written 'ε' or '()'.
+*/
+type Epsilon struct{}
+
+/*
+	Matches a single symbol.
+*/
+type Literal struct {
+	Symbol nfa.Symbol
+}
+
+/*
+	Matches any single symbol of a set, written '[abc]', '[a-c]' or '[^ab]'.
+*/
+type Class struct {
+	Symbols []nfa.Symbol
+}
+
+/*
+	Matches the left node followed by the right node.
+*/
+type Concat struct {
+	Left  Node
+	Right Node
+}
+
+/*
+	Matches either the left node or the right node, written 'a|b'.
+*/
+type Union struct {
+	Left  Node
+	Right Node
+}
+
+/*
+	Matches zero or more repetitions of a node, written 'a*'.
+*/
+type Star struct {
+	Node Node
+}
+
+/*
+	Matches one or more repetitions of a node, written 'a+'.
+*/
+type Plus struct {
+	Node Node
+}
+
+/*
+	Matches zero or one occurrence of a node, written 'a?'.
+*/
+type Optional struct {
+	Node Node
+}
+
+func (EmptySet) node() {}
+func (Epsilon) node()  {}
+func (Literal) node()  {}
+func (Class) node()    {}
+func (Concat) node()   {}
+func (Union) node()    {}
+func (Star) node()     {}
+func (Plus) node()     {}
+func (Optional) node() {}
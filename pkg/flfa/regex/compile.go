@@ -0,0 +1,191 @@
+package regex
+
+import (
+	"flfa/nfa"
+	"fmt"
+)
+
+/*
+	A piece of the NFA under construction with a single entry state and a single exit state.
+*/
+type fragment struct {
+	start  int
+	accept int
+}
+
+type builder struct {
+	alphabet    []nfa.Symbol
+	transitions []map[nfa.Symbol][]int
+}
+
+/*
+	Parses a pattern over the given alphabet and compiles it into an epsilon-NFA.
+*/
+func Compile(pattern string, alphabet []nfa.Symbol) (nfa.NFA, error) {
+	node, err := Parse(pattern, alphabet)
+	if err != nil {
+		return nfa.NFA{}, err
+	}
+
+	return ToNFA(node, alphabet)
+}
+
+/*
+	Compiles a syntax tree into an epsilon-NFA using Thompson's construction.
+	The states are named 'q0', 'q1', ... in the order they are created, and there is one starting and one accepting state.
+*/
+func ToNFA(node Node, alphabet []nfa.Symbol) (nfa.NFA, error) {
+	b := builder{alphabet: alphabet}
+
+	f, err := b.build(node)
+	if err != nil {
+		return nfa.NFA{}, err
+	}
+
+	states := make([]nfa.State, len(b.transitions))
+	for i := range states {
+		states[i] = nfa.State(fmt.Sprintf("q%v", i))
+	}
+
+	delta := make(nfa.SetDelta, len(states))
+	for i, transitions := range b.transitions {
+		delta[states[i]] = make(map[nfa.Symbol]nfa.StateSet, len(alphabet)+1)
+
+		for _, symbol := range alphabet {
+			delta[states[i]][symbol] = nfa.NewStateSet(transitions[symbol]...)
+		}
+
+		if newStates, ok := transitions[nfa.Epsilon]; ok {
+			delta[states[i]][nfa.Epsilon] = nfa.NewStateSet(newStates...)
+		}
+	}
+
+	return nfa.NewNFAFromSets(states, alphabet, delta, nfa.NewStateSet(f.start), nfa.NewStateSet(f.accept))
+}
+
+/*
+	Builds the fragment for a node.
+*/
+func (b *builder) build(node Node) (fragment, error) {
+	switch node := node.(type) {
+	case EmptySet:
+		return fragment{b.newState(), b.newState()}, nil
+	case Epsilon:
+		f := fragment{b.newState(), b.newState()}
+		b.addTransition(f.start, nfa.Epsilon, f.accept)
+
+		return f, nil
+	case Literal:
+		return b.buildSymbols([]nfa.Symbol{node.Symbol})
+	case Class:
+		return b.buildSymbols(node.Symbols)
+	case Concat:
+		left, err := b.build(node.Left)
+		if err != nil {
+			return fragment{}, err
+		}
+
+		right, err := b.build(node.Right)
+		if err != nil {
+			return fragment{}, err
+		}
+
+		b.addTransition(left.accept, nfa.Epsilon, right.start)
+
+		return fragment{left.start, right.accept}, nil
+	case Union:
+		start := b.newState()
+
+		left, err := b.build(node.Left)
+		if err != nil {
+			return fragment{}, err
+		}
+
+		right, err := b.build(node.Right)
+		if err != nil {
+			return fragment{}, err
+		}
+
+		accept := b.newState()
+		b.addTransition(start, nfa.Epsilon, left.start)
+		b.addTransition(start, nfa.Epsilon, right.start)
+		b.addTransition(left.accept, nfa.Epsilon, accept)
+		b.addTransition(right.accept, nfa.Epsilon, accept)
+
+		return fragment{start, accept}, nil
+	case Star:
+		return b.buildRepeat(node.Node, true, true)
+	case Plus:
+		return b.buildRepeat(node.Node, false, true)
+	case Optional:
+		return b.buildRepeat(node.Node, true, false)
+	}
+
+	return fragment{}, fmt.Errorf("the node '%T' is not a regular expression node", node)
+}
+
+/*
+	Builds a fragment that reads any one of the symbols.
+*/
+func (b *builder) buildSymbols(symbols []nfa.Symbol) (fragment, error) {
+	f := fragment{b.newState(), b.newState()}
+
+	for _, symbol := range symbols {
+		if !b.isInAlphabet(symbol) {
+			return fragment{}, fmt.Errorf("the symbol '%v' is not within the alphabet", string(symbol))
+		}
+
+		b.addTransition(f.start, symbol, f.accept)
+	}
+
+	return f, nil
+}
+
+/*
+	Builds a fragment around a node that can optionally be skipped and optionally be repeated.
+*/
+func (b *builder) buildRepeat(node Node, canSkip bool, canRepeat bool) (fragment, error) {
+	start := b.newState()
+
+	inner, err := b.build(node)
+	if err != nil {
+		return fragment{}, err
+	}
+
+	accept := b.newState()
+	b.addTransition(start, nfa.Epsilon, inner.start)
+	b.addTransition(inner.accept, nfa.Epsilon, accept)
+
+	if canSkip {
+		b.addTransition(start, nfa.Epsilon, accept)
+	}
+
+	if canRepeat {
+		b.addTransition(inner.accept, nfa.Epsilon, inner.start)
+	}
+
+	return fragment{start, accept}, nil
+}
+
+func (b *builder) newState() int {
+	b.transitions = append(b.transitions, make(map[nfa.Symbol][]int))
+
+	return len(b.transitions) - 1
+}
+
+func (b *builder) addTransition(from int, symbol nfa.Symbol, to int) {
+	b.transitions[from][symbol] = append(b.transitions[from][symbol], to)
+}
+
+/*
+	Checks if a symbol is in the builder's alphabet.
+*/
+func (b *builder) isInAlphabet(symbol nfa.Symbol) bool {
+	for _, acceptedSymbol := range b.alphabet {
+		if acceptedSymbol == symbol {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,132 @@
+package regex
+
+import (
+	"flfa/dfa"
+	"flfa/nfa"
+)
+
+/*
+	Converts a DFA into an equivalent syntax tree by state elimination.
+	The DFA is minimized first, and the states with the fewest paths through them are eliminated first to keep the result short.
+	If the DFA fails validation, then nil is returned.
+*/
+func FromDFA(d dfa.DFA) (Node, error) {
+	minimized, _, err := d.Minimize()
+	if err != nil {
+		return nil, err
+	}
+
+	states := minimized.States()
+	alphabet := minimized.Alphabet()
+
+	// Index 0 is a new starting state, index 1 a new accepting state and the rest are the DFA's states
+	start, accept := 0, 1
+	size := len(states) + 2
+
+	stateIndices := make(map[dfa.State]int, len(states))
+	for i, state := range states {
+		stateIndices[state] = i + 2
+	}
+
+	// edges[i][j] is the expression for moving from i to j, where nil means there is no edge
+	edges := make([][]Node, size)
+	for i := range edges {
+		edges[i] = make([]Node, size)
+	}
+
+	edges[start][stateIndices[minimized.StartingState()]] = Epsilon{}
+
+	for _, state := range states {
+		i := stateIndices[state]
+
+		if minimized.IsAccepting(state) {
+			edges[i][accept] = Epsilon{}
+		}
+
+		// Parallel edges are grouped into a class in the order of the alphabet
+		grouped := make(map[int][]nfa.Symbol)
+		for _, symbol := range alphabet {
+			j := stateIndices[minimized.Transition(state, symbol)]
+			grouped[j] = append(grouped[j], nfa.Symbol(symbol))
+		}
+
+		for j, symbols := range grouped {
+			edges[i][j] = Simplify(Class{symbols})
+		}
+	}
+
+	remaining := make(map[int]bool, len(states))
+	for _, state := range states {
+		remaining[stateIndices[state]] = true
+	}
+
+	for len(remaining) != 0 {
+		k := cheapestState(edges, remaining)
+		delete(remaining, k)
+
+		var through Node = Epsilon{}
+		if edges[k][k] != nil {
+			through = star(edges[k][k])
+		}
+
+		for i := 0; i < size; i++ {
+			if i == k || edges[i][k] == nil {
+				continue
+			}
+
+			for j := 0; j < size; j++ {
+				if j == k || edges[k][j] == nil {
+					continue
+				}
+
+				path := concat(concat(edges[i][k], through), edges[k][j])
+				if edges[i][j] == nil {
+					edges[i][j] = path
+				} else {
+					edges[i][j] = union(edges[i][j], path)
+				}
+			}
+		}
+
+		for i := 0; i < size; i++ {
+			edges[i][k] = nil
+			edges[k][i] = nil
+		}
+	}
+
+	if edges[start][accept] == nil {
+		return EmptySet{}, nil
+	}
+
+	return edges[start][accept], nil
+}
+
+/*
+	Finds the remaining state with the fewest paths through it, preferring the earliest state on ties.
+*/
+func cheapestState(edges [][]Node, remaining map[int]bool) int {
+	cheapest, cheapestCost := -1, 0
+
+	for k := range edges {
+		if !remaining[k] {
+			continue
+		}
+
+		incoming, outgoing := 0, 0
+		for i := range edges {
+			if i != k && edges[i][k] != nil {
+				incoming++
+			}
+
+			if i != k && edges[k][i] != nil {
+				outgoing++
+			}
+		}
+
+		if cost := incoming * outgoing; cheapest == -1 || cost < cheapestCost {
+			cheapest, cheapestCost = k, cost
+		}
+	}
+
+	return cheapest
+}
@@ -0,0 +1,162 @@
+package regex
+
+import (
+	"flfa/dfa"
+	"flfa/nfa"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/*
+	Creates the DFA of binary numbers equal to 2 modulo 7.
+*/
+func new2Mod7DFA(t *testing.T) dfa.DFA {
+	t.Helper()
+
+	states := []dfa.State{"q0", "q1", "q2", "q3", "q4", "q5", "q6"}
+	delta := make(dfa.Delta, len(states))
+
+	for i, state := range states {
+		delta[state] = map[dfa.Symbol]dfa.State{
+			'0': states[(2*i)%7],
+			'1': states[(2*i+1)%7],
+		}
+	}
+
+	d, err := dfa.NewDFA(states, []dfa.Symbol{'0', '1'}, delta, "q0", []dfa.State{"q2"})
+	require.NoError(t, err)
+
+	return d
+}
+
+/*
+	Checks that a converted expression prints, parses back and accepts the same language as the DFA.
+*/
+func assertRoundTrip(t *testing.T, d dfa.DFA) {
+	node, err := FromDFA(d)
+	assert.Equal(t, nil, err)
+
+	alphabet := make([]nfa.Symbol, 0, len(d.Alphabet()))
+	for _, symbol := range d.Alphabet() {
+		alphabet = append(alphabet, nfa.Symbol(symbol))
+	}
+
+	compiled, err := Compile(node.String(), alphabet)
+	assert.Equal(t, nil, err, "pattern '%v'", node)
+
+	converted, err := compiled.ToDFA()
+	assert.Equal(t, nil, err)
+
+	isEquivalent, counterexample, err := dfa.Equivalent(d, converted)
+	assert.Equal(t, nil, err)
+	assert.True(t, isEquivalent, "pattern '%v' differs on %v", node, counterexample)
+}
+
+func TestFromDFA(t *testing.T) {
+	endsInAB, err := dfa.NewDFA(
+		[]dfa.State{"q0", "q1", "q2"},
+		[]dfa.Symbol{'a', 'b'},
+		dfa.Delta{
+			"q0": {
+				'a': "q1",
+				'b': "q0",
+			},
+			"q1": {
+				'a': "q1",
+				'b': "q2",
+			},
+			"q2": {
+				'a': "q1",
+				'b': "q0",
+			},
+		},
+		"q0",
+		[]dfa.State{"q2"},
+	)
+	assert.Equal(t, nil, err)
+
+	nothing, err := dfa.NewDFA(
+		[]dfa.State{"q0"},
+		[]dfa.Symbol{'a'},
+		dfa.Delta{
+			"q0": {
+				'a': "q0",
+			},
+		},
+		"q0",
+		nil,
+	)
+	assert.Equal(t, nil, err)
+
+	everything, err := dfa.NewDFA(
+		[]dfa.State{"q0", "q1"},
+		[]dfa.Symbol{'a', 'b'},
+		dfa.Delta{
+			"q0": {
+				'a': "q1",
+				'b': "q1",
+			},
+			"q1": {
+				'a': "q0",
+				'b': "q0",
+			},
+		},
+		"q0",
+		[]dfa.State{"q0", "q1"},
+	)
+	assert.Equal(t, nil, err)
+
+	node, err := FromDFA(nothing)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "∅", node.String())
+
+	node, err = FromDFA(everything)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "[ab]*", node.String())
+
+	for _, d := range []dfa.DFA{endsInAB, nothing, everything, new2Mod7DFA(t)} {
+		assertRoundTrip(t, d)
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	var tests = []struct {
+		node Node
+		want string
+	}{
+		{Star{Star{Literal{'a'}}}, "a*"},
+		{Star{Plus{Literal{'a'}}}, "a*"},
+		{Union{EmptySet{}, Literal{'a'}}, "a"},
+		{Concat{EmptySet{}, Literal{'a'}}, "∅"},
+		{Concat{Epsilon{}, Concat{Literal{'a'}, Epsilon{}}}, "a"},
+		{Union{Epsilon{}, Concat{Literal{'a'}, Literal{'b'}}}, "(ab)?"},
+		{Union{Literal{'a'}, Class{[]nfa.Symbol{'b', 'a'}}}, "[ab]"},
+		{Concat{Literal{'a'}, Star{Literal{'a'}}}, "a+"},
+		{Star{EmptySet{}}, "ε"},
+		{Union{Concat{Literal{'a'}, Literal{'b'}}, Concat{Literal{'a'}, Literal{'b'}}}, "ab"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Simplify(tt.node).String())
+	}
+}
+
+func TestString(t *testing.T) {
+	var tests = []struct {
+		node Node
+		want string
+	}{
+		{Concat{Union{Literal{'a'}, Literal{'b'}}, Literal{'c'}}, "(a|b)c"},
+		{Union{Concat{Literal{'a'}, Literal{'b'}}, Literal{'c'}}, "ab|c"},
+		{Star{Concat{Literal{'a'}, Literal{'b'}}}, "(ab)*"},
+		{Optional{Star{Literal{'a'}}}, "a*?"},
+		{Concat{Literal{'*'}, Class{[]nfa.Symbol{']', '-'}}}, "\\*[\\]\\-]"},
+		{Class{nil}, "∅"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.node.String())
+	}
+}
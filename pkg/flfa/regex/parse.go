@@ -0,0 +1,322 @@
+package regex
+
+import (
+	"flfa/nfa"
+	"fmt"
+)
+
+/*
+	An error found while parsing a pattern, along with the position of the offending rune.
+*/
+type ParseError struct {
+	Pattern string
+	Pos     int
+	Msg     string
+}
+
+func (err *ParseError) Error() string {
+	return fmt.Sprintf("%v at position %v in the pattern '%v'", err.Msg, err.Pos, err.Pattern)
+}
+
+type parser struct {
+	pattern  []rune
+	pos      int
+	alphabet []nfa.Symbol
+}
+
+/*
+	Parses a pattern over the given alphabet into a syntax tree.
+	The pattern supports union '|', concatenation, the repetitions '*', '+' and '?', grouping '()',
+	character classes '[abc]', '[a-c]' and '[^ab]', and the constants 'ε' and '∅'.
+	Metacharacters are matched literally when escaped with '\'.
+	Positions in errors count runes from zero.
+*/
+func Parse(pattern string, alphabet []nfa.Symbol) (Node, error) {
+	p := parser{[]rune(pattern), 0, alphabet}
+
+	node, err := p.parseUnion()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.done() {
+		return nil, p.errorf("unexpected '%v'", string(p.peek()))
+	}
+
+	return node, nil
+}
+
+/*
+	Parses alternatives separated by '|'.
+*/
+func (p *parser) parseUnion() (Node, error) {
+	left, err := p.parseConcat()
+	if err != nil {
+		return nil, err
+	}
+
+	for !p.done() && p.peek() == '|' {
+		p.pos++
+
+		right, err := p.parseConcat()
+		if err != nil {
+			return nil, err
+		}
+
+		left = Union{left, right}
+	}
+
+	return left, nil
+}
+
+/*
+	Parses a possibly empty sequence of repetitions.
+*/
+func (p *parser) parseConcat() (Node, error) {
+	var left Node
+
+	for !p.done() && p.peek() != '|' && p.peek() != ')' {
+		right, err := p.parseRepeat()
+		if err != nil {
+			return nil, err
+		}
+
+		if left == nil {
+			left = right
+		} else {
+			left = Concat{left, right}
+		}
+	}
+
+	if left == nil {
+		return Epsilon{}, nil
+	}
+
+	return left, nil
+}
+
+/*
+	Parses an atom followed by any number of '*', '+' and '?'.
+*/
+func (p *parser) parseRepeat() (Node, error) {
+	node, err := p.parseAtom()
+	if err != nil {
+		return nil, err
+	}
+
+	for !p.done() {
+		switch p.peek() {
+		case '*':
+			node = Star{node}
+		case '+':
+			node = Plus{node}
+		case '?':
+			node = Optional{node}
+		default:
+			return node, nil
+		}
+
+		p.pos++
+	}
+
+	return node, nil
+}
+
+/*
+	Parses a symbol, an escaped symbol, a group, a character class or a constant.
+*/
+func (p *parser) parseAtom() (Node, error) {
+	char := p.peek()
+
+	switch char {
+	case '(':
+		start := p.pos
+		p.pos++
+
+		node, err := p.parseUnion()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.done() {
+			p.pos = start
+			return nil, p.errorf("missing ')' for the group")
+		}
+
+		p.pos++
+
+		return node, nil
+	case '[':
+		return p.parseClass()
+	case '*', '+', '?':
+		return nil, p.errorf("missing expression before '%v'", string(char))
+	case ']':
+		return nil, p.errorf("unexpected ']'")
+	case 'ε':
+		p.pos++
+		return Epsilon{}, nil
+	case '∅':
+		p.pos++
+		return EmptySet{}, nil
+	}
+
+	symbol, err := p.parseSymbol()
+	if err != nil {
+		return nil, err
+	}
+
+	return Literal{symbol}, nil
+}
+
+/*
+	Parses a character class, such as '[abc]', '[a-c]' or '[^ab]'.
+*/
+func (p *parser) parseClass() (Node, error) {
+	start := p.pos
+	p.pos++
+
+	isNegated := false
+	if !p.done() && p.peek() == '^' {
+		isNegated = true
+		p.pos++
+	}
+
+	listed := make(map[nfa.Symbol]bool)
+
+	for {
+		if p.done() {
+			p.pos = start
+			return nil, p.errorf("missing ']' for the character class")
+		}
+
+		if p.peek() == ']' {
+			break
+		}
+
+		lowPos := p.pos
+		low, err := p.parseClassRune()
+		if err != nil {
+			return nil, err
+		}
+
+		high := low
+		if !p.done() && p.peek() == '-' {
+			p.pos++
+
+			if p.done() || p.peek() == ']' {
+				return nil, p.errorf("missing the end of the range")
+			}
+
+			high, err = p.parseClassRune()
+			if err != nil {
+				return nil, err
+			}
+
+			if high < low {
+				p.pos = lowPos
+				return nil, p.errorf("the range '%v-%v' is out of order", string(low), string(high))
+			}
+		} else if !p.isInAlphabet(nfa.Symbol(low)) {
+			p.pos = lowPos
+			return nil, p.errorf("the symbol '%v' is not within the alphabet", string(low))
+		}
+
+		for _, symbol := range p.alphabet {
+			if rune(symbol) >= low && rune(symbol) <= high {
+				listed[symbol] = true
+			}
+		}
+	}
+
+	if p.pos == start+1 || (isNegated && p.pos == start+2) {
+		return nil, p.errorf("the character class is empty")
+	}
+
+	p.pos++
+
+	// The symbols are kept in the order of the alphabet
+	var symbols []nfa.Symbol
+	for _, symbol := range p.alphabet {
+		if listed[symbol] != isNegated {
+			symbols = append(symbols, symbol)
+		}
+	}
+
+	return Class{symbols}, nil
+}
+
+/*
+	Parses a possibly escaped rune inside a character class.
+*/
+func (p *parser) parseClassRune() (rune, error) {
+	char := p.peek()
+
+	if char == '\\' {
+		p.pos++
+
+		if p.done() {
+			return 0, p.errorf("missing the escaped symbol")
+		}
+
+		char = p.peek()
+	}
+
+	p.pos++
+
+	return char, nil
+}
+
+/*
+	Parses a possibly escaped symbol of the alphabet.
+*/
+func (p *parser) parseSymbol() (nfa.Symbol, error) {
+	start := p.pos
+	char := p.peek()
+
+	if char == '\\' {
+		p.pos++
+
+		if p.done() {
+			return 0, p.errorf("missing the escaped symbol")
+		}
+
+		char = p.peek()
+	}
+
+	if !p.isInAlphabet(nfa.Symbol(char)) {
+		p.pos = start
+		return 0, p.errorf("the symbol '%v' is not within the alphabet", string(char))
+	}
+
+	p.pos++
+
+	return nfa.Symbol(char), nil
+}
+
+/*
+	Checks if a symbol is in the parser's alphabet.
+*/
+func (p *parser) isInAlphabet(symbol nfa.Symbol) bool {
+	for _, acceptedSymbol := range p.alphabet {
+		if acceptedSymbol == symbol {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.pattern)
+}
+
+func (p *parser) peek() rune {
+	return p.pattern[p.pos]
+}
+
+/*
+	Creates a parse error at the parser's current position.
+*/
+func (p *parser) errorf(format string, a ...interface{}) error {
+	return &ParseError{string(p.pattern), p.pos, fmt.Sprintf(format, a...)}
+}
@@ -0,0 +1,101 @@
+package regex
+
+import (
+	"flfa/nfa"
+	"strings"
+)
+
+// The precedence of each kind of node, where a higher precedence binds tighter
+const (
+	unionPrecedence = iota
+	concatPrecedence
+	repeatPrecedence
+	atomPrecedence
+)
+
+func (EmptySet) String() string {
+	return "∅"
+}
+
+func (Epsilon) String() string {
+	return "ε"
+}
+
+func (node Literal) String() string {
+	return escape(node.Symbol, "|*+?()[]\\ε∅")
+}
+
+func (node Class) String() string {
+	if len(node.Symbols) == 0 {
+		return EmptySet{}.String()
+	}
+
+	var builder strings.Builder
+	builder.WriteRune('[')
+
+	for _, symbol := range node.Symbols {
+		builder.WriteString(escape(symbol, "]\\-^"))
+	}
+
+	builder.WriteRune(']')
+
+	return builder.String()
+}
+
+func (node Concat) String() string {
+	return format(node.Left, concatPrecedence) + format(node.Right, concatPrecedence)
+}
+
+func (node Union) String() string {
+	return format(node.Left, unionPrecedence) + "|" + format(node.Right, unionPrecedence)
+}
+
+func (node Star) String() string {
+	return format(node.Node, repeatPrecedence) + "*"
+}
+
+func (node Plus) String() string {
+	return format(node.Node, repeatPrecedence) + "+"
+}
+
+func (node Optional) String() string {
+	return format(node.Node, repeatPrecedence) + "?"
+}
+
+/*
+	Formats a child node, grouping it if it binds looser than its parent.
+*/
+func format(node Node, parentPrecedence int) string {
+	if precedence(node) < parentPrecedence {
+		return "(" + node.String() + ")"
+	}
+
+	return node.String()
+}
+
+/*
+	Finds the precedence of a node.
+*/
+func precedence(node Node) int {
+	switch node.(type) {
+	case Union:
+		return unionPrecedence
+	case Concat:
+		return concatPrecedence
+	case Star, Plus, Optional:
+		return repeatPrecedence
+	}
+
+	return atomPrecedence
+}
+
+/*
+	Escapes a symbol with '\' if it is one of the metacharacters.
+*/
+func escape(symbol nfa.Symbol, metacharacters string) string {
+	if strings.ContainsRune(metacharacters, rune(symbol)) {
+		return "\\" + string(symbol)
+	}
+
+	return string(symbol)
+}
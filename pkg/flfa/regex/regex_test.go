@@ -0,0 +1,128 @@
+package regex
+
+import (
+	"flfa/internal/testutil"
+	"flfa/nfa"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var abc = []nfa.Symbol{'a', 'b', 'c'}
+
+func TestParse(t *testing.T) {
+	var tests = []struct {
+		pattern string
+		want    Node
+	}{
+		{"a", Literal{'a'}},
+		{"ab|c", Union{Concat{Literal{'a'}, Literal{'b'}}, Literal{'c'}}},
+		{"a(b|)*", Concat{Literal{'a'}, Star{Union{Literal{'b'}, Epsilon{}}}}},
+		{"a+?", Optional{Plus{Literal{'a'}}}},
+		{"[c-a]", nil},
+		{"[a-b]c", Concat{Class{[]nfa.Symbol{'a', 'b'}}, Literal{'c'}}},
+		{"[^b]", Class{[]nfa.Symbol{'a', 'c'}}},
+		{"[ca]", Class{[]nfa.Symbol{'a', 'c'}}},
+		{"()|∅", Union{Epsilon{}, EmptySet{}}},
+		{"ε", Epsilon{}},
+	}
+
+	for _, tt := range tests {
+		node, err := Parse(tt.pattern, abc)
+
+		if tt.want == nil {
+			assert.Error(t, err, "pattern '%v'", tt.pattern)
+		} else {
+			assert.Equal(t, nil, err, "pattern '%v'", tt.pattern)
+			assert.Equal(t, tt.want, node, "pattern '%v'", tt.pattern)
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	var tests = []struct {
+		pattern string
+		pos     int
+		msg     string
+	}{
+		{"ab)", 2, "unexpected ')'"},
+		{"a(b|c", 1, "missing ')' for the group"},
+		{"a|*b", 2, "missing expression before '*'"},
+		{"ad", 1, "the symbol 'd' is not within the alphabet"},
+		{"[ab", 0, "missing ']' for the character class"},
+		{"a[]", 2, "the character class is empty"},
+		{"[c-a]", 1, "the range 'c-a' is out of order"},
+		{"[ad]", 2, "the symbol 'd' is not within the alphabet"},
+		{"ab\\", 3, "missing the escaped symbol"},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.pattern, abc)
+
+		assert.Equal(t, &ParseError{tt.pattern, tt.pos, tt.msg}, err)
+	}
+
+	_, err := Parse("a(b", abc)
+	assert.EqualError(t, err, "missing ')' for the group at position 1 in the pattern 'a(b'")
+}
+
+func TestParseEscape(t *testing.T) {
+	node, err := Parse("\\*a\\|", []nfa.Symbol{'a', '*', '|'})
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, Concat{Concat{Literal{'*'}, Literal{'a'}}, Literal{'|'}}, node)
+}
+
+func TestCompile(t *testing.T) {
+	patterns := []string{
+		"a",
+		"abc",
+		"a|b|c",
+		"(ab|c)*",
+		"a*b+c?",
+		"(a|b)*abb",
+		"[ab]*c[^a]",
+		"(a*)*",
+		"((a|)b)+",
+		"[a-c]?[b-c]+",
+	}
+
+	for _, pattern := range patterns {
+		compiled, err := Compile(pattern, abc)
+		assert.Equal(t, nil, err, "pattern '%v'", pattern)
+
+		oracle := regexp.MustCompile("^(?:" + pattern + ")$")
+
+		for _, str := range testutil.Strings(abc, 5) {
+			_, isAccepting, err := compiled.Solve(str)
+
+			assert.Equal(t, nil, err)
+			assert.Equal(t, oracle.MatchString(str), isAccepting, "pattern '%v' and string '%v'", pattern, str)
+		}
+	}
+}
+
+func TestCompileConstants(t *testing.T) {
+	var tests = []struct {
+		pattern string
+		accepts map[string]bool
+	}{
+		{"ε", map[string]bool{"": true, "a": false}},
+		{"∅", map[string]bool{"": false, "a": false}},
+		{"a∅|b", map[string]bool{"a": false, "b": true}},
+		{"∅*", map[string]bool{"": true, "a": false}},
+	}
+
+	for _, tt := range tests {
+		compiled, err := Compile(tt.pattern, abc)
+		assert.Equal(t, nil, err)
+
+		for str, want := range tt.accepts {
+			_, isAccepting, err := compiled.Solve(str)
+
+			assert.Equal(t, nil, err)
+			assert.Equal(t, want, isAccepting, "pattern '%v' and string '%v'", tt.pattern, str)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package regex
+
+import (
+	"flfa/nfa"
+	"reflect"
+)
+
+/*
+	Rewrites a syntax tree bottom up with simple algebraic rules that keep its language:
+	∅ is the identity of '|' and absorbs concatenation, ε is the identity of concatenation,
+	'r|r' becomes 'r', 'ε|r' becomes 'r?', 'rr*' becomes 'r+', and nested repetitions such as '(r*)*' become 'r*'.
+*/
+func Simplify(node Node) Node {
+	switch node := node.(type) {
+	case Class:
+		if len(node.Symbols) == 0 {
+			return EmptySet{}
+		}
+
+		if len(node.Symbols) == 1 {
+			return Literal{node.Symbols[0]}
+		}
+	case Concat:
+		return concat(Simplify(node.Left), Simplify(node.Right))
+	case Union:
+		return union(Simplify(node.Left), Simplify(node.Right))
+	case Star:
+		return star(Simplify(node.Node))
+	case Plus:
+		return plus(Simplify(node.Node))
+	case Optional:
+		return optional(Simplify(node.Node))
+	}
+
+	return node
+}
+
+/*
+	Creates the union of two simplified nodes.
+*/
+func union(left Node, right Node) Node {
+	switch {
+	case isEmptySet(left):
+		return right
+	case isEmptySet(right):
+		return left
+	case reflect.DeepEqual(left, right):
+		return left
+	case isEpsilon(left):
+		return optional(right)
+	case isEpsilon(right):
+		return optional(left)
+	}
+
+	// Literals and classes merge into one class
+	leftSymbols, isLeftSymbols := symbols(left)
+	rightSymbols, isRightSymbols := symbols(right)
+	if isLeftSymbols && isRightSymbols {
+		merged := append([]nfa.Symbol(nil), leftSymbols...)
+		for _, symbol := range rightSymbols {
+			if !containsSymbol(merged, symbol) {
+				merged = append(merged, symbol)
+			}
+		}
+
+		return Class{merged}
+	}
+
+	return Union{left, right}
+}
+
+/*
+	Creates the concatenation of two simplified nodes.
+*/
+func concat(left Node, right Node) Node {
+	switch {
+	case isEmptySet(left) || isEmptySet(right):
+		return EmptySet{}
+	case isEpsilon(left):
+		return right
+	case isEpsilon(right):
+		return left
+	}
+
+	if repeated, ok := right.(Star); ok && reflect.DeepEqual(left, repeated.Node) {
+		return Plus{left}
+	}
+
+	if repeated, ok := left.(Star); ok && reflect.DeepEqual(repeated.Node, right) {
+		return Plus{right}
+	}
+
+	return Concat{left, right}
+}
+
+/*
+	Creates the Kleene star of a simplified node.
+*/
+func star(node Node) Node {
+	switch node := node.(type) {
+	case EmptySet, Epsilon:
+		return Epsilon{}
+	case Star:
+		return node
+	case Plus:
+		return Star{node.Node}
+	case Optional:
+		return Star{node.Node}
+	}
+
+	return Star{node}
+}
+
+/*
+	Creates one or more repetitions of a simplified node.
+*/
+func plus(node Node) Node {
+	switch node := node.(type) {
+	case EmptySet, Epsilon:
+		return node
+	case Star, Plus:
+		return node
+	case Optional:
+		return Star{node.Node}
+	}
+
+	return Plus{node}
+}
+
+/*
+	Creates zero or one occurrence of a simplified node.
+*/
+func optional(node Node) Node {
+	switch node := node.(type) {
+	case EmptySet, Epsilon:
+		return Epsilon{}
+	case Star, Optional:
+		return node
+	case Plus:
+		return Star{node.Node}
+	}
+
+	return Optional{node}
+}
+
+func isEmptySet(node Node) bool {
+	_, ok := node.(EmptySet)
+
+	return ok
+}
+
+func isEpsilon(node Node) bool {
+	_, ok := node.(Epsilon)
+
+	return ok
+}
+
+/*
+	Gets the symbols of a literal or a class.
+*/
+func symbols(node Node) ([]nfa.Symbol, bool) {
+	switch node := node.(type) {
+	case Literal:
+		return []nfa.Symbol{node.Symbol}, true
+	case Class:
+		return node.Symbols, true
+	}
+
+	return nil, false
+}
+
+/*
+	Checks if a symbol is in a symbol array.
+*/
+func containsSymbol(symbols []nfa.Symbol, symbol nfa.Symbol) bool {
+	for _, possibleSymbol := range symbols {
+		if possibleSymbol == symbol {
+			return true
+		}
+	}
+
+	return false
+}
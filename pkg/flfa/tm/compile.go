@@ -0,0 +1,372 @@
+package tm
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+	The first of the symbols the single-tape machine of a multi-tape machine stores the cells of every tape in.
+	The symbols are in the supplementary private use areas, so they are not in any alphabet meant to be read.
+*/
+const firstTrackSymbol Symbol = 0xF0000
+
+/*
+	The phases of the single-tape machine simulating one move of a multi-tape machine.
+*/
+type phase int
+
+const (
+	// Encodes the first input symbol, marking the heads of every track on it.
+	phaseInit phase = iota
+	// Encodes the other input symbols.
+	phaseInitRest
+	// Moves left to the left end of the encoded cells.
+	phaseRewind
+	// Moves right over the encoded cells, reading the symbols under the heads.
+	phaseRead
+	// Moves left over the encoded cells, writing the symbols under the heads and moving the heads.
+	phaseWrite
+	// Marks the heads that moved right on the cell to the right.
+	phaseRight
+	// Moves back left after marking the heads that moved right.
+	phaseBack
+)
+
+/*
+	A state of the single-tape machine: a phase, the state of the multi-tape machine, the symbols read so far on the tracks known,
+	and while writing, the tracks done, the tracks whose heads move onto the next cell to the left and the ones that moved right.
+*/
+type simulation struct {
+	phase   phase
+	state   State
+	read    string
+	known   uint
+	done    uint
+	pending uint
+	right   uint
+}
+
+/*
+	Builds the single-tape machine of a multi-tape machine, naming its states and finding their transitions.
+*/
+type compiler struct {
+	tm           *MultiTapeTM
+	tapeAlphabet []Symbol
+	tracks       []Symbol
+	names        map[simulation]State
+	isUsed       map[State]bool
+	states       []State
+	delta        Delta
+	queue        []simulation
+}
+
+/*
+	Compiles a multi-tape TM into a single-tape TM that accepts and rejects the same strings, and runs forever on the others.
+	Every cell of the single tape holds a track for every tape, with the symbol of that tape and whether its head is on the cell.
+	For every move of the multi-tape machine, the single-tape machine sweeps right reading the symbols under the heads,
+	then sweeps left writing them and moving the heads, and then moves back to the left end of the tracks.
+*/
+func (tm *MultiTapeTM) ToSingleTape() (TM, error) {
+	err := tm.validate()
+	if err != nil {
+		return TM{}, err
+	}
+
+	count := 1
+	for i := 0; i < tm.tapes; i++ {
+		count *= 2 * len(tm.tapeAlphabet)
+
+		if int(firstTrackSymbol)+count-1 > 0x10FFFD {
+			return TM{}, fmt.Errorf("the machine of %v tapes and %v tape symbols needs too many symbols", tm.tapes, len(tm.tapeAlphabet))
+		}
+	}
+
+	for _, symbol := range tm.tapeAlphabet {
+		if symbol >= firstTrackSymbol && int(symbol) < int(firstTrackSymbol)+count {
+			return TM{}, fmt.Errorf("the symbol '%c' is reserved for the tracks of the single tape", symbol)
+		}
+	}
+
+	compiler := compiler{tm, nil, nil, make(map[simulation]State), map[State]bool{tm.acceptingState: true, tm.rejectingState: true}, nil, make(Delta), nil}
+	for i := 0; i < count; i++ {
+		compiler.tracks = append(compiler.tracks, firstTrackSymbol+Symbol(i))
+	}
+
+	compiler.tapeAlphabet = append(append([]Symbol(nil), tm.tapeAlphabet...), compiler.tracks...)
+
+	start := compiler.state(simulation{phase: phaseInit})
+	for len(compiler.queue) != 0 {
+		simulation := compiler.queue[0]
+		compiler.queue = compiler.queue[1:]
+
+		compiler.compile(simulation)
+	}
+
+	states := append(compiler.states, tm.acceptingState, tm.rejectingState)
+
+	return NewTM(states, tm.inputAlphabet, compiler.tapeAlphabet, compiler.delta, tm.blank, start, tm.acceptingState, tm.rejectingState)
+}
+
+/*
+	Finds the transitions of a state of the single-tape machine.
+*/
+func (compiler *compiler) compile(s simulation) {
+	tm := compiler.tm
+	all := uint(1)<<tm.tapes - 1
+	name := compiler.names[s]
+	compiler.delta[name] = make(map[Symbol]Action)
+
+	blanks := make([]Symbol, tm.tapes)
+	for i := range blanks {
+		blanks[i] = tm.blank
+	}
+
+	switch s.phase {
+	case phaseInit, phaseInitRest:
+		for _, symbol := range append(tm.InputAlphabet(), tm.blank) {
+			symbols := append([]Symbol{symbol}, blanks[1:]...)
+
+			switch {
+			case symbol != tm.blank && s.phase == phaseInit:
+				compiler.delta[name][symbol] = Action{compiler.state(simulation{phase: phaseInitRest}), compiler.track(symbols, all), Right}
+			case symbol != tm.blank:
+				compiler.delta[name][symbol] = Action{name, compiler.track(symbols, 0), Right}
+			case s.phase == phaseInit:
+				compiler.delta[name][symbol] = Action{compiler.after(tm.startingState), compiler.track(symbols, all), Left}
+			default:
+				compiler.delta[name][symbol] = Action{compiler.after(tm.startingState), symbol, Left}
+			}
+		}
+	case phaseRewind:
+		for _, track := range compiler.tracks {
+			compiler.delta[name][track] = Action{name, track, Left}
+		}
+
+		compiler.delta[name][tm.blank] = Action{compiler.state(simulation{phase: phaseRead, state: s.state, read: string(toRunes(blanks))}), tm.blank, Right}
+	case phaseRead:
+		for _, track := range compiler.tracks {
+			symbols, marks := compiler.decode(track)
+			if marks&s.known != 0 {
+				continue
+			}
+
+			read := []rune(s.read)
+			for i := range symbols {
+				if marks&(1<<i) != 0 {
+					read[i] = rune(symbols[i])
+				}
+			}
+
+			next := simulation{phase: phaseRead, state: s.state, read: string(read), known: s.known | marks}
+			compiler.delta[name][track] = Action{compiler.state(next), track, Right}
+		}
+
+		if s.known == all {
+			if _, ok := tm.delta[s.state][s.read]; ok {
+				compiler.delta[name][tm.blank] = Action{compiler.state(simulation{phase: phaseWrite, state: s.state, read: s.read}), tm.blank, Left}
+			} else {
+				compiler.delta[name][tm.blank] = Action{tm.rejectingState, tm.blank, Stay}
+			}
+		}
+	case phaseWrite:
+		action := tm.delta[s.state][s.read]
+
+		for _, symbol := range append(compiler.tracks, tm.blank) {
+			symbols, marks := compiler.decode(symbol)
+			if marks&s.pending != 0 {
+				continue
+			}
+
+			done, pending, right := s.done, uint(0), uint(0)
+			written := marks | s.pending
+
+			for i := range symbols {
+				bit := uint(1) << i
+				if marks&bit == 0 || done&bit != 0 {
+					continue
+				}
+
+				symbols[i] = action.Write[i]
+				done |= bit
+
+				switch action.Moves[i] {
+				case Left:
+					written &^= bit
+					pending |= bit
+				case Right:
+					written &^= bit
+					right |= bit
+				}
+			}
+
+			if right != 0 {
+				next := simulation{phase: phaseRight, state: s.state, read: s.read, done: done, pending: pending, right: right}
+				compiler.delta[name][symbol] = Action{compiler.state(next), compiler.track(symbols, written), Right}
+			} else {
+				compiler.delta[name][symbol] = Action{compiler.continuation(s, done, pending), compiler.track(symbols, written), Left}
+			}
+		}
+	case phaseRight:
+		for _, symbol := range append(compiler.tracks, tm.blank) {
+			symbols, marks := compiler.decode(symbol)
+			if marks&s.right != 0 {
+				continue
+			}
+
+			next := simulation{phase: phaseBack, state: s.state, read: s.read, done: s.done, pending: s.pending}
+			compiler.delta[name][symbol] = Action{compiler.state(next), compiler.track(symbols, marks|s.right), Left}
+		}
+	case phaseBack:
+		for _, track := range compiler.tracks {
+			compiler.delta[name][track] = Action{compiler.continuation(s, s.done, s.pending), track, Left}
+		}
+	}
+}
+
+/*
+	Gets the state that goes on writing after a cell, or that starts the next move once every track is done.
+*/
+func (compiler *compiler) continuation(s simulation, done uint, pending uint) State {
+	if done == uint(1)<<compiler.tm.tapes-1 && pending == 0 {
+		return compiler.after(compiler.tm.delta[s.state][s.read].State)
+	}
+
+	return compiler.state(simulation{phase: phaseWrite, state: s.state, read: s.read, done: done, pending: pending})
+}
+
+/*
+	Gets the state entered once the multi-tape machine enters a state, which halts if the state is halting and rewinds otherwise.
+*/
+func (compiler *compiler) after(state State) State {
+	if state == compiler.tm.acceptingState || state == compiler.tm.rejectingState {
+		return state
+	}
+
+	return compiler.state(simulation{phase: phaseRewind, state: state})
+}
+
+/*
+	Gets the name of a state of the single-tape machine, queueing it to be compiled if it is new.
+	Names get primes added until they are not the name of another state.
+*/
+func (compiler *compiler) state(s simulation) State {
+	if name, ok := compiler.names[s]; ok {
+		return name
+	}
+
+	name := State(s.name(compiler.tm.tapes))
+	for compiler.isUsed[name] {
+		name += "'"
+	}
+
+	compiler.names[s] = name
+	compiler.isUsed[name] = true
+	compiler.states = append(compiler.states, name)
+	compiler.queue = append(compiler.queue, s)
+
+	return name
+}
+
+/*
+	Gets the symbol of a cell holding a symbol on every track, with the heads of the marked tracks on it.
+*/
+func (compiler *compiler) track(symbols []Symbol, marks uint) Symbol {
+	index := 0
+	for i := len(symbols) - 1; i >= 0; i-- {
+		index = index*2*len(compiler.tm.tapeAlphabet) + 2*compiler.indexOf(symbols[i]) + int(marks>>i&1)
+	}
+
+	return compiler.tracks[index]
+}
+
+/*
+	Gets the symbol on every track of a cell and the tracks whose heads are on it.
+	A symbol of the multi-tape machine is a cell of blank tracks past the ends of the tracks.
+*/
+func (compiler *compiler) decode(symbol Symbol) ([]Symbol, uint) {
+	symbols := make([]Symbol, compiler.tm.tapes)
+	if symbol < firstTrackSymbol {
+		for i := range symbols {
+			symbols[i] = compiler.tm.blank
+		}
+
+		return symbols, 0
+	}
+
+	index := int(symbol - firstTrackSymbol)
+	marks := uint(0)
+
+	for i := range symbols {
+		marks |= uint(index%2) << i
+		index /= 2
+		symbols[i] = compiler.tm.tapeAlphabet[index%len(compiler.tm.tapeAlphabet)]
+		index /= len(compiler.tm.tapeAlphabet)
+	}
+
+	return symbols, marks
+}
+
+/*
+	Gets the index of a symbol in the tape alphabet of the multi-tape machine.
+*/
+func (compiler *compiler) indexOf(symbol Symbol) int {
+	for i, possibleSymbol := range compiler.tm.tapeAlphabet {
+		if symbol == possibleSymbol {
+			return i
+		}
+	}
+
+	return -1
+}
+
+/*
+	Formats a state of the single-tape machine for its name, as in 'read(q,a?)' or 'write(q,ab,10,00)',
+	where '?' is a track not read yet and the bits of a set of tracks start with the first track.
+*/
+func (s simulation) name(tapes int) string {
+	bits := func(set uint) string {
+		var builder strings.Builder
+		for i := 0; i < tapes; i++ {
+			builder.WriteByte(byte('0' + set>>i&1))
+		}
+
+		return builder.String()
+	}
+
+	read := []rune(s.read)
+	for i := range read {
+		if s.phase == phaseRead && s.known&(1<<i) == 0 {
+			read[i] = '?'
+		}
+	}
+
+	switch s.phase {
+	case phaseInit:
+		return "init"
+	case phaseInitRest:
+		return "init-rest"
+	case phaseRewind:
+		return fmt.Sprintf("rewind(%v)", s.state)
+	case phaseRead:
+		return fmt.Sprintf("read(%v,%v)", s.state, string(read))
+	case phaseWrite:
+		return fmt.Sprintf("write(%v,%v,%v,%v)", s.state, string(read), bits(s.done), bits(s.pending))
+	case phaseRight:
+		return fmt.Sprintf("right(%v,%v,%v,%v,%v)", s.state, string(read), bits(s.done), bits(s.pending), bits(s.right))
+	}
+
+	return fmt.Sprintf("back(%v,%v,%v,%v)", s.state, string(read), bits(s.done), bits(s.pending))
+}
+
+/*
+	Converts symbols into runes.
+*/
+func toRunes(symbols []Symbol) []rune {
+	runes := make([]rune, len(symbols))
+	for i, symbol := range symbols {
+		runes[i] = rune(symbol)
+	}
+
+	return runes
+}
@@ -0,0 +1,102 @@
+package tm
+
+import (
+	"flfa/internal/testutil"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+	Asserts that a multi-tape machine and its single-tape machine have the same outcome on every string of up to the given length.
+*/
+func assertSameOutcomes(t *testing.T, multiTapeTM MultiTapeTM, length int, limit int) {
+	tm, err := multiTapeTM.ToSingleTape()
+	assert.Equal(t, nil, err)
+
+	for _, str := range testutil.Strings(multiTapeTM.InputAlphabet(), length) {
+		want, err := multiTapeTM.Solve(str, limit)
+		assert.Equal(t, nil, err, "string '%v'", str)
+
+		result, err := tm.Solve(str, 1000*limit)
+		assert.Equal(t, nil, err, "string '%v'", str)
+		assert.Equal(t, want.Outcome, result.Outcome, "string '%v'", str)
+	}
+}
+
+func TestToSingleTape(t *testing.T) {
+	assertSameOutcomes(t, newPalindromeTM(t), 6, 100)
+
+	multiTapeTM := newPalindromeTM(t)
+
+	tm, err := multiTapeTM.ToSingleTape()
+	assert.Equal(t, nil, err)
+
+	// The first cell has the symbol 'a' on the first track and a blank on the second, with both heads on it
+	trace, result, err := tm.Trace("ab", 1000)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, Rejected, result.Outcome)
+	assert.Equal(t, Configuration{"init", "ab", 0}, trace[0])
+	assert.Equal(t, string(rune(firstTrackSymbol+(2*0+1)+(2*2+1)*6))+"b", trace[1].Tape)
+}
+
+func TestToSingleTapeThreeTapes(t *testing.T) {
+	// The machine accepts the strings a^n b^n, counting the a's on the second tape and copying the b's to the third one
+	delta := MultiTapeDelta{
+		"a": {
+			"a__": {"a", []Symbol{'a', 'a', '_'}, []Move{Right, Right, Stay}},
+			"b__": {"b", []Symbol{'b', '_', '_'}, []Move{Stay, Left, Stay}},
+			"___": {"accept", []Symbol{'_', '_', '_'}, []Move{Stay, Stay, Stay}},
+		},
+		"b": {
+			"ba_": {"b", []Symbol{'b', 'a', 'b'}, []Move{Right, Left, Right}},
+			"___": {"accept", []Symbol{'_', '_', '_'}, []Move{Stay, Stay, Stay}},
+		},
+	}
+
+	multiTapeTM, err := NewMultiTapeTM(3, []State{"a", "b", "accept", "reject"}, []Symbol{'a', 'b'}, []Symbol{'a', 'b', '_'}, delta, '_', "a", "accept", "reject")
+	assert.Equal(t, nil, err)
+
+	for _, str := range []string{"", "ab", "aabb", "aab", "abb", "ba"} {
+		result, err := multiTapeTM.Solve(str, 100)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, str == "" || str == "ab" || str == "aabb", result.Outcome == Accepted, "string '%v'", str)
+	}
+
+	assertSameOutcomes(t, multiTapeTM, 5, 100)
+}
+
+func TestToSingleTapeDidNotHalt(t *testing.T) {
+	// The second head moves right forever
+	multiTapeTM, err := NewMultiTapeTM(2, []State{"q", "accept", "reject"}, []Symbol{'a'}, []Symbol{'a', '_'}, MultiTapeDelta{
+		"q": {
+			"a_": {"q", []Symbol{'a', '_'}, []Move{Stay, Right}},
+			"__": {"q", []Symbol{'_', '_'}, []Move{Stay, Right}},
+		},
+	}, '_', "q", "accept", "reject")
+	assert.Equal(t, nil, err)
+
+	tm, err := multiTapeTM.ToSingleTape()
+	assert.Equal(t, nil, err)
+
+	for _, str := range []string{"", "a", "aa"} {
+		result, err := tm.Solve(str, 10000)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, DidNotHalt, result.Outcome)
+	}
+}
+
+func TestToSingleTapeErrors(t *testing.T) {
+	multiTapeTM, err := NewMultiTapeTM(2, []State{"q", "accept", "reject"}, []Symbol{'a'}, []Symbol{'a', '_', firstTrackSymbol + 3}, MultiTapeDelta{}, '_', "q", "accept", "reject")
+	assert.Equal(t, nil, err)
+
+	_, err = multiTapeTM.ToSingleTape()
+	assert.Equal(t, fmt.Errorf("the symbol '%c' is reserved for the tracks of the single tape", firstTrackSymbol+3), err)
+
+	multiTapeTM, err = NewMultiTapeTM(9, []State{"q", "accept", "reject"}, []Symbol{'a'}, []Symbol{'a', '_'}, MultiTapeDelta{}, '_', "q", "accept", "reject")
+	assert.Equal(t, nil, err)
+
+	_, err = multiTapeTM.ToSingleTape()
+	assert.Equal(t, fmt.Errorf("the machine of 9 tapes and 2 tape symbols needs too many symbols"), err)
+}
@@ -0,0 +1,262 @@
+package tm
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+	What a multi-tape machine does in a state reading a symbol on every tape:
+	the state it enters, the symbol it writes on every tape and the way every head moves.
+*/
+type MultiTapeAction struct {
+	State State
+	Write []Symbol
+	Moves []Move
+}
+
+/*
+	The transitions of a multi-tape machine, keyed by the symbols under the heads as a string, the first tape first.
+*/
+type MultiTapeDelta map[State]map[string]MultiTapeAction
+
+/*
+	A deterministic Turing machine with a number of tapes, each with its own head.
+	The input is on the first tape, the other tapes start blank, and every head starts at position 0.
+	Like a TM, the machine halts in the accepting or the rejecting state, and a missing transition is a move into the rejecting state.
+*/
+type MultiTapeTM struct {
+	tapes          int
+	states         []State
+	inputAlphabet  []Symbol
+	tapeAlphabet   []Symbol
+	delta          MultiTapeDelta
+	blank          Symbol
+	startingState  State
+	acceptingState State
+	rejectingState State
+}
+
+/*
+	A snapshot of a run of a multi-tape machine, with the contents of every tape and the index of its head in them.
+*/
+type MultiTapeConfiguration struct {
+	State State
+	Tapes []string
+	Heads []int
+}
+
+/*
+	The result of running a multi-tape machine with a step limit, with the configuration it ended in.
+*/
+type MultiTapeResult struct {
+	Outcome       Outcome
+	Steps         int
+	Configuration MultiTapeConfiguration
+}
+
+/*
+	Creates a multi-tape TM and validates it.
+	If the TM fails validation, then an empty TM is returned.
+*/
+func NewMultiTapeTM(tapes int, states []State, inputAlphabet []Symbol, tapeAlphabet []Symbol, delta MultiTapeDelta, blank Symbol, startingState State, acceptingState State, rejectingState State) (MultiTapeTM, error) {
+	tm := MultiTapeTM{tapes, states, inputAlphabet, tapeAlphabet, delta, blank, startingState, acceptingState, rejectingState}
+
+	err := tm.validate()
+	if err != nil {
+		return MultiTapeTM{}, err
+	}
+
+	return tm, nil
+}
+
+/*
+	Gets the number of tapes of the TM.
+*/
+func (tm *MultiTapeTM) Tapes() int {
+	return tm.tapes
+}
+
+/*
+	Validates and solves a multi-tape TM given a string, making at most the step limit moves.
+*/
+func (tm *MultiTapeTM) Solve(str string, limit int) (MultiTapeResult, error) {
+	_, result, err := tm.run(str, limit, false)
+
+	return result, err
+}
+
+/*
+	Validates and solves a multi-tape TM given a string like Solve, also returning every configuration of the run from the starting one.
+*/
+func (tm *MultiTapeTM) Trace(str string, limit int) ([]MultiTapeConfiguration, MultiTapeResult, error) {
+	return tm.run(str, limit, true)
+}
+
+/*
+	Runs the TM on a string for at most the step limit moves, keeping every configuration if asked to.
+*/
+func (tm *MultiTapeTM) run(str string, limit int, isTraced bool) ([]MultiTapeConfiguration, MultiTapeResult, error) {
+	err := tm.validate()
+	if err != nil {
+		return nil, MultiTapeResult{}, err
+	}
+
+	if limit < 0 {
+		return nil, MultiTapeResult{}, fmt.Errorf("the step limit '%v' must not be negative", limit)
+	}
+
+	for _, symbol := range str {
+		err = validateSymbol(Symbol(symbol), tm.inputAlphabet, "input")
+		if err != nil {
+			return nil, MultiTapeResult{}, err
+		}
+	}
+
+	state := tm.startingState
+	tapes := []*tape{newTape(str, tm.blank)}
+	for len(tapes) < tm.tapes {
+		tapes = append(tapes, newTape("", tm.blank))
+	}
+	heads := make([]int, tm.tapes)
+
+	var trace []MultiTapeConfiguration
+	if isTraced {
+		trace = append(trace, configurationOf(state, tapes, heads))
+	}
+
+	steps := 0
+	for ; steps < limit && state != tm.acceptingState && state != tm.rejectingState; steps++ {
+		read := make([]rune, tm.tapes)
+		for i, tape := range tapes {
+			read[i] = rune(tape.read(heads[i]))
+		}
+
+		action, ok := tm.delta[state][string(read)]
+		if !ok {
+			state = tm.rejectingState
+		} else {
+			for i, tape := range tapes {
+				tape.write(heads[i], action.Write[i])
+				heads[i] = move(heads[i], action.Moves[i])
+			}
+
+			state = action.State
+		}
+
+		if isTraced {
+			trace = append(trace, configurationOf(state, tapes, heads))
+		}
+	}
+
+	outcome := DidNotHalt
+	switch state {
+	case tm.acceptingState:
+		outcome = Accepted
+	case tm.rejectingState:
+		outcome = Rejected
+	}
+
+	return trace, MultiTapeResult{outcome, steps, configurationOf(state, tapes, heads)}, nil
+}
+
+/*
+	Gets the configuration of a state, tapes and heads.
+*/
+func configurationOf(state State, tapes []*tape, heads []int) MultiTapeConfiguration {
+	configuration := MultiTapeConfiguration{state, make([]string, len(tapes)), make([]int, len(tapes))}
+	for i, tape := range tapes {
+		configuration.Tapes[i], configuration.Heads[i] = tape.contents(heads[i])
+	}
+
+	return configuration
+}
+
+/*
+	Formats the configuration as the configuration of every tape separated by ' | ', as in 'ab[q1]ba | [q1]_'.
+*/
+func (configuration MultiTapeConfiguration) String() string {
+	tapes := make([]string, len(configuration.Tapes))
+	for i := range configuration.Tapes {
+		tapes[i] = Configuration{configuration.State, configuration.Tapes[i], configuration.Heads[i]}.String()
+	}
+
+	return strings.Join(tapes, " | ")
+}
+
+/*
+	Validates the multi-tape TM.
+*/
+func (tm *MultiTapeTM) validate() error {
+	if tm.tapes < 1 {
+		return fmt.Errorf("the number of tapes '%v' must be positive", tm.tapes)
+	}
+
+	err := validateAlphabets(tm.inputAlphabet, tm.tapeAlphabet, tm.blank)
+	if err != nil {
+		return err
+	}
+
+	for _, state := range []State{tm.startingState, tm.acceptingState, tm.rejectingState} {
+		err = validateState(state, tm.states)
+		if err != nil {
+			return err
+		}
+	}
+
+	if tm.acceptingState == tm.rejectingState {
+		return fmt.Errorf("the accepting and rejecting states cannot both be '%v'", tm.acceptingState)
+	}
+
+	for state, transitions := range tm.delta {
+		err = validateState(state, tm.states)
+		if err != nil {
+			return err
+		}
+
+		if (state == tm.acceptingState || state == tm.rejectingState) && len(transitions) != 0 {
+			return fmt.Errorf("the halting state '%v' cannot have transitions", state)
+		}
+
+		for read, action := range transitions {
+			err = tm.validateTransition(read, action)
+			if err != nil {
+				return fmt.Errorf("the transition of '%v' reading '%v' is invalid: %v", state, read, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+/*
+	Validates the symbols read, the symbols written and the moves of a transition against the number of tapes.
+*/
+func (tm *MultiTapeTM) validateTransition(read string, action MultiTapeAction) error {
+	if len([]rune(read)) != tm.tapes || len(action.Write) != tm.tapes || len(action.Moves) != tm.tapes {
+		return fmt.Errorf("it must read, write and move on all %v tapes", tm.tapes)
+	}
+
+	for _, symbol := range read {
+		err := validateSymbol(Symbol(symbol), tm.tapeAlphabet, "tape")
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < tm.tapes; i++ {
+		err := validateAction(Action{action.State, action.Write[i], action.Moves[i]}, tm.states, tm.tapeAlphabet)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/*
+	Gets a copy of the TM's input alphabet.
+*/
+func (tm *MultiTapeTM) InputAlphabet() []Symbol {
+	return append([]Symbol(nil), tm.inputAlphabet...)
+}
@@ -0,0 +1,127 @@
+package tm
+
+import (
+	"flfa/internal/testutil"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/*
+	Creates a two-tape machine that accepts the palindromes over a and b.
+	It copies the input onto the second tape, moves the first head back, and then compares the first tape forward with the second backward.
+*/
+func newPalindromeTM(t *testing.T) MultiTapeTM {
+	t.Helper()
+
+	delta := MultiTapeDelta{
+		"copy": {
+			"a_": {"copy", []Symbol{'a', 'a'}, []Move{Right, Right}},
+			"b_": {"copy", []Symbol{'b', 'b'}, []Move{Right, Right}},
+			"__": {"rewind", []Symbol{'_', '_'}, []Move{Left, Stay}},
+		},
+		"rewind": {
+			"a_": {"rewind", []Symbol{'a', '_'}, []Move{Left, Stay}},
+			"b_": {"rewind", []Symbol{'b', '_'}, []Move{Left, Stay}},
+			"__": {"compare", []Symbol{'_', '_'}, []Move{Right, Left}},
+		},
+		"compare": {
+			"aa": {"compare", []Symbol{'a', 'a'}, []Move{Right, Left}},
+			"bb": {"compare", []Symbol{'b', 'b'}, []Move{Right, Left}},
+			"__": {"accept", []Symbol{'_', '_'}, []Move{Stay, Stay}},
+		},
+	}
+
+	tm, err := NewMultiTapeTM(2, []State{"copy", "rewind", "compare", "accept", "reject"}, []Symbol{'a', 'b'}, []Symbol{'a', 'b', '_'}, delta, '_', "copy", "accept", "reject")
+	require.NoError(t, err)
+
+	return tm
+}
+
+/*
+	Checks if a string reads the same backward.
+*/
+func isPalindrome(str string) bool {
+	for i := 0; i < len(str)/2; i++ {
+		if str[i] != str[len(str)-1-i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestMultiTapeSolve(t *testing.T) {
+	tm := newPalindromeTM(t)
+
+	for _, str := range testutil.Strings([]Symbol{'a', 'b'}, 6) {
+		want := Rejected
+		if isPalindrome(str) {
+			want = Accepted
+		}
+
+		result, err := tm.Solve(str, 100)
+		assert.Equal(t, nil, err, "string '%v'", str)
+		assert.Equal(t, want, result.Outcome, "string '%v'", str)
+	}
+
+	result, err := tm.Solve("abba", 100)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, MultiTapeResult{Accepted, 15, MultiTapeConfiguration{"accept", []string{"abba_", "_abba"}, []int{4, 0}}}, result)
+
+	result, err = tm.Solve("abba", 10)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, DidNotHalt, result.Outcome)
+	assert.Equal(t, 10, result.Steps)
+
+	_, err = tm.Solve("abc", 100)
+	assert.Equal(t, fmt.Errorf("the symbol 'c' is not in the input alphabet"), err)
+}
+
+func TestMultiTapeTrace(t *testing.T) {
+	tm := newPalindromeTM(t)
+
+	trace, result, err := tm.Trace("ab", 100)
+	assert.Equal(t, nil, err)
+
+	var configurations []string
+	for _, configuration := range trace {
+		configurations = append(configurations, configuration.String())
+	}
+
+	assert.Equal(t, []string{
+		"[copy]ab | [copy]_",
+		"a[copy]b | a[copy]_",
+		"ab[copy]_ | ab[copy]_",
+		"a[rewind]b | ab[rewind]_",
+		"[rewind]ab | ab[rewind]_",
+		"[rewind]_ab | ab[rewind]_",
+		"[compare]ab | a[compare]b",
+		"[reject]ab | a[reject]b",
+	}, configurations)
+	assert.Equal(t, Rejected, result.Outcome)
+}
+
+func TestNewMultiTapeTM(t *testing.T) {
+	var tests = []struct {
+		tapes int
+		delta MultiTapeDelta
+		want  error
+	}{
+		{2, MultiTapeDelta{"q": {"a_": {"q", []Symbol{'a', 'a'}, []Move{Right, Stay}}}}, nil},
+		{0, MultiTapeDelta{}, fmt.Errorf("the number of tapes '0' must be positive")},
+		{2, MultiTapeDelta{"q": {"a": {"q", []Symbol{'a', 'a'}, []Move{Right, Stay}}}}, fmt.Errorf("the transition of 'q' reading 'a' is invalid: it must read, write and move on all 2 tapes")},
+		{2, MultiTapeDelta{"q": {"a_": {"q", []Symbol{'a'}, []Move{Right, Stay}}}}, fmt.Errorf("the transition of 'q' reading 'a_' is invalid: it must read, write and move on all 2 tapes")},
+		{2, MultiTapeDelta{"q": {"ab": {"q", []Symbol{'a', 'a'}, []Move{Right, Stay}}}}, fmt.Errorf("the transition of 'q' reading 'ab' is invalid: the symbol 'b' is not in the tape alphabet")},
+		{2, MultiTapeDelta{"q": {"a_": {"q", []Symbol{'a', 'a'}, []Move{Right, Move(5)}}}}, fmt.Errorf("the transition of 'q' reading 'a_' is invalid: the move 'Move(5)' must be either left, right or stay")},
+		{2, MultiTapeDelta{"accept": {"a_": {"q", []Symbol{'a', 'a'}, []Move{Right, Stay}}}}, fmt.Errorf("the halting state 'accept' cannot have transitions")},
+	}
+
+	for _, tt := range tests {
+		_, err := NewMultiTapeTM(tt.tapes, []State{"q", "accept", "reject"}, []Symbol{'a'}, []Symbol{'a', '_'}, tt.delta, '_', "q", "accept", "reject")
+
+		assert.Equal(t, tt.want, err)
+	}
+}
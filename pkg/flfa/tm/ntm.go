@@ -0,0 +1,206 @@
+package tm
+
+import (
+	"fmt"
+)
+
+/*
+	The transitions of a nondeterministic machine, where a state reading a symbol may do any of a number of actions.
+*/
+type NTMDelta map[State]map[Symbol][]Action
+
+/*
+	A single-tape nondeterministic Turing machine, which accepts a string if any of its computations accepts it.
+	A computation halts when it enters the accepting or the rejecting state, or when it has no action to do.
+*/
+type NTM struct {
+	states         []State
+	inputAlphabet  []Symbol
+	tapeAlphabet   []Symbol
+	delta          NTMDelta
+	blank          Symbol
+	startingState  State
+	acceptingState State
+	rejectingState State
+}
+
+/*
+	The result of simulating a nondeterministic machine.
+	If a computation accepts, then its configurations from the starting one to the accepting one are given.
+	The outcome is rejected if every computation rejects within the bound, and did not halt otherwise.
+*/
+type NTMResult struct {
+	Outcome Outcome
+	Path    []Configuration
+}
+
+/*
+	A configuration reached in the search, with the configuration it was first reached from.
+*/
+type branch struct {
+	state  State
+	tape   *tape
+	head   int
+	parent int
+}
+
+/*
+	Creates an NTM and validates it.
+	If the NTM fails validation, then an empty NTM is returned.
+*/
+func NewNTM(states []State, inputAlphabet []Symbol, tapeAlphabet []Symbol, delta NTMDelta, blank Symbol, startingState State, acceptingState State, rejectingState State) (NTM, error) {
+	ntm := NTM{states, inputAlphabet, tapeAlphabet, delta, blank, startingState, acceptingState, rejectingState}
+
+	err := ntm.validate()
+	if err != nil {
+		return NTM{}, err
+	}
+
+	return ntm, nil
+}
+
+/*
+	Validates and solves an NTM given a string, searching its computations breadth-first.
+	Only computations of at most the step bound moves are followed, so that the search ends even when computations do not halt.
+	A configuration is explored once, so a computation that loops through configurations it has already been in is treated as rejecting.
+*/
+func (ntm *NTM) Solve(str string, bound int) (NTMResult, error) {
+	err := ntm.validate()
+	if err != nil {
+		return NTMResult{}, err
+	}
+
+	if bound < 0 {
+		return NTMResult{}, fmt.Errorf("the step bound '%v' must not be negative", bound)
+	}
+
+	for _, symbol := range str {
+		err = validateSymbol(Symbol(symbol), ntm.inputAlphabet, "input")
+		if err != nil {
+			return NTMResult{}, err
+		}
+	}
+
+	branches := []branch{{ntm.startingState, newTape(str, ntm.blank), 0, -1}}
+	isVisited := map[string]bool{branches[0].key(): true}
+
+	isBoundReached := false
+	frontier := []int{0}
+
+	for steps := 0; len(frontier) != 0; steps++ {
+		var next []int
+
+		for _, i := range frontier {
+			current := branches[i]
+
+			if current.state == ntm.acceptingState {
+				return NTMResult{Accepted, ntm.path(branches, i)}, nil
+			}
+
+			if current.state == ntm.rejectingState {
+				continue
+			}
+
+			actions := ntm.delta[current.state][current.tape.read(current.head)]
+			if steps == bound {
+				if len(actions) != 0 {
+					isBoundReached = true
+				}
+
+				continue
+			}
+
+			for _, action := range actions {
+				successor := branch{action.State, current.tape.clone(), move(current.head, action.Move), i}
+				successor.tape.write(current.head, action.Write)
+
+				key := successor.key()
+				if isVisited[key] {
+					continue
+				}
+
+				isVisited[key] = true
+				branches = append(branches, successor)
+				next = append(next, len(branches)-1)
+			}
+		}
+
+		frontier = next
+	}
+
+	if isBoundReached {
+		return NTMResult{DidNotHalt, nil}, nil
+	}
+
+	return NTMResult{Rejected, nil}, nil
+}
+
+/*
+	Rebuilds the configurations from the starting one to the given branch.
+*/
+func (ntm *NTM) path(branches []branch, i int) []Configuration {
+	var path []Configuration
+	for ; i != -1; i = branches[i].parent {
+		path = append([]Configuration{branches[i].configuration()}, path...)
+	}
+
+	return path
+}
+
+/*
+	Gets the configuration of a branch.
+*/
+func (branch branch) configuration() Configuration {
+	tape, head := branch.tape.contents(branch.head)
+
+	return Configuration{branch.state, tape, head}
+}
+
+/*
+	Gets a key that identifies the configuration of a branch.
+	Configurations that differ by where the tape is shifted to behave the same, so they have the same key.
+*/
+func (branch branch) key() string {
+	configuration := branch.configuration()
+
+	return fmt.Sprintf("%q %q %v", configuration.State, configuration.Tape, configuration.Head)
+}
+
+/*
+	Validates the NTM.
+*/
+func (ntm *NTM) validate() error {
+	deterministic := TM{ntm.states, ntm.inputAlphabet, ntm.tapeAlphabet, nil, ntm.blank, ntm.startingState, ntm.acceptingState, ntm.rejectingState}
+
+	err := deterministic.validate()
+	if err != nil {
+		return err
+	}
+
+	for state, transitions := range ntm.delta {
+		err = validateState(state, ntm.states)
+		if err != nil {
+			return err
+		}
+
+		if (state == ntm.acceptingState || state == ntm.rejectingState) && len(transitions) != 0 {
+			return fmt.Errorf("the halting state '%v' cannot have transitions", state)
+		}
+
+		for symbol, actions := range transitions {
+			err = validateSymbol(symbol, ntm.tapeAlphabet, "tape")
+			if err != nil {
+				return err
+			}
+
+			for _, action := range actions {
+				err = validateAction(action, ntm.states, ntm.tapeAlphabet)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
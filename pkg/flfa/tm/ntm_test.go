@@ -0,0 +1,112 @@
+package tm
+
+import (
+	"flfa/internal/testutil"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/*
+	Creates a machine that accepts the strings with two equal adjacent symbols, guessing where the first of them is.
+*/
+func newRepeatedSymbolNTM(t *testing.T) NTM {
+	t.Helper()
+
+	delta := NTMDelta{
+		"q": {'a': {{"q", 'a', Right}, {"p", 'a', Right}}, 'b': {{"q", 'b', Right}, {"r", 'b', Right}}},
+		"p": {'a': {{"accept", 'a', Stay}}},
+		"r": {'b': {{"accept", 'b', Stay}}},
+	}
+
+	ntm, err := NewNTM([]State{"q", "p", "r", "accept", "reject"}, []Symbol{'a', 'b'}, []Symbol{'a', 'b', '_'}, delta, '_', "q", "accept", "reject")
+	require.NoError(t, err)
+
+	return ntm
+}
+
+func TestNTMSolve(t *testing.T) {
+	ntm := newRepeatedSymbolNTM(t)
+
+	for _, str := range testutil.Strings([]Symbol{'a', 'b'}, 6) {
+		want := Rejected
+		if strings.Contains(str, "aa") || strings.Contains(str, "bb") {
+			want = Accepted
+		}
+
+		result, err := ntm.Solve(str, 100)
+		assert.Equal(t, nil, err, "string '%v'", str)
+		assert.Equal(t, want, result.Outcome, "string '%v'", str)
+	}
+
+	// The path is the shortest accepting computation
+	result, err := ntm.Solve("abba", 100)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, Accepted, result.Outcome)
+	assert.Equal(t, []Configuration{
+		{"q", "abba", 0},
+		{"q", "abba", 1},
+		{"r", "abba", 2},
+		{"accept", "abba", 2},
+	}, result.Path)
+
+	// Too small a bound does not reach the accepting state
+	result, err = ntm.Solve("abba", 2)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, DidNotHalt, result.Outcome)
+	assert.Equal(t, []Configuration(nil), result.Path)
+}
+
+func TestNTMSolveLoops(t *testing.T) {
+	// At the end of the string the machine may stay on the blank forever or walk right forever
+	delta := NTMDelta{
+		"q":    {'a': {{"q", 'a', Right}}, '_': {{"q", '_', Stay}, {"walk", '_', Right}}},
+		"walk": {'_': {{"walk", '_', Right}}},
+	}
+
+	ntm, err := NewNTM([]State{"q", "walk", "accept", "reject"}, []Symbol{'a'}, []Symbol{'a', '_'}, delta, '_', "q", "accept", "reject")
+	assert.Equal(t, nil, err)
+
+	result, err := ntm.Solve("aa", 50)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, DidNotHalt, result.Outcome)
+
+	// Without the walk, the computation that stays is in the same configuration forever and counts as rejecting
+	delta["q"]['_'] = []Action{{"q", '_', Stay}}
+
+	ntm, err = NewNTM([]State{"q", "walk", "accept", "reject"}, []Symbol{'a'}, []Symbol{'a', '_'}, delta, '_', "q", "accept", "reject")
+	assert.Equal(t, nil, err)
+
+	result, err = ntm.Solve("aa", 50)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, Rejected, result.Outcome)
+}
+
+func TestNewNTM(t *testing.T) {
+	var tests = []struct {
+		delta NTMDelta
+		want  error
+	}{
+		{NTMDelta{"p": {}}, fmt.Errorf("the state 'p' is not in the states")},
+		{NTMDelta{"accept": {'a': {{"q", 'a', Left}}}}, fmt.Errorf("the halting state 'accept' cannot have transitions")},
+		{NTMDelta{"q": {'b': {{"q", 'a', Left}}}}, fmt.Errorf("the symbol 'b' is not in the tape alphabet")},
+		{NTMDelta{"q": {'a': {{"q", 'a', Left}, {"q", 'b', Left}}}}, fmt.Errorf("the symbol 'b' is not in the tape alphabet")},
+		{NTMDelta{"q": {'a': {{"q", 'a', Move(3)}}}}, fmt.Errorf("the move 'Move(3)' must be either left, right or stay")},
+	}
+
+	for _, tt := range tests {
+		_, err := NewNTM([]State{"q", "accept", "reject"}, []Symbol{'a'}, []Symbol{'a', '_'}, tt.delta, '_', "q", "accept", "reject")
+		assert.Equal(t, tt.want, err)
+	}
+
+	ntm := newRepeatedSymbolNTM(t)
+
+	_, err := ntm.Solve("aa", -1)
+	assert.Equal(t, fmt.Errorf("the step bound '-1' must not be negative"), err)
+
+	_, err = ntm.Solve("ac", 10)
+	assert.Equal(t, fmt.Errorf("the symbol 'c' is not in the input alphabet"), err)
+}
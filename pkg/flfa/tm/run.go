@@ -0,0 +1,189 @@
+package tm
+
+import (
+	"fmt"
+)
+
+/*
+	How a run of a machine ended.
+*/
+type Outcome int
+
+const (
+	Accepted Outcome = iota
+	Rejected
+	DidNotHalt
+)
+
+/*
+	A snapshot of a run: the state, the tape from the leftmost to the rightmost cell that is not blank or under the head,
+	and the index of the head in the tape.
+*/
+type Configuration struct {
+	State State
+	Tape  string
+	Head  int
+}
+
+/*
+	The result of running a machine with a step limit, with the configuration it ended in.
+*/
+type Result struct {
+	Outcome       Outcome
+	Steps         int
+	Configuration Configuration
+}
+
+/*
+	A run of a TM on an input, which is advanced one step at a time.
+*/
+type Run struct {
+	tm    *TM
+	state State
+	tape  *tape
+	head  int
+	steps int
+}
+
+/*
+	Validates a TM and starts a run of it on a string.
+*/
+func (tm *TM) NewRun(str string) (*Run, error) {
+	err := tm.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, symbol := range str {
+		err = validateSymbol(Symbol(symbol), tm.inputAlphabet, "input")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Run{tm, tm.startingState, newTape(str, tm.blank), 0, 0}, nil
+}
+
+/*
+	Makes one move, unless the run has halted.
+	Reports if a move was made.
+*/
+func (run *Run) Step() bool {
+	if run.IsHalted() {
+		return false
+	}
+
+	action, ok := run.tm.delta[run.state][run.tape.read(run.head)]
+	if !ok {
+		action = Action{run.tm.rejectingState, run.tape.read(run.head), Stay}
+	}
+
+	run.tape.write(run.head, action.Write)
+	run.state = action.State
+	run.head = move(run.head, action.Move)
+	run.steps++
+
+	return true
+}
+
+/*
+	Checks if the run is in the accepting or the rejecting state.
+*/
+func (run *Run) IsHalted() bool {
+	return run.state == run.tm.acceptingState || run.state == run.tm.rejectingState
+}
+
+/*
+	Gets the number of moves made so far.
+*/
+func (run *Run) Steps() int {
+	return run.steps
+}
+
+/*
+	Gets the current configuration of the run.
+*/
+func (run *Run) Configuration() Configuration {
+	tape, head := run.tape.contents(run.head)
+
+	return Configuration{run.state, tape, head}
+}
+
+/*
+	Gets the outcome of the run so far, where a run that has not halted yet did not halt.
+*/
+func (run *Run) Outcome() Outcome {
+	switch run.state {
+	case run.tm.acceptingState:
+		return Accepted
+	case run.tm.rejectingState:
+		return Rejected
+	}
+
+	return DidNotHalt
+}
+
+/*
+	Validates and solves a TM given a string, making at most the step limit moves.
+*/
+func (tm *TM) Solve(str string, limit int) (Result, error) {
+	if limit < 0 {
+		return Result{}, fmt.Errorf("the step limit '%v' must not be negative", limit)
+	}
+
+	run, err := tm.NewRun(str)
+	if err != nil {
+		return Result{}, err
+	}
+
+	for run.Steps() < limit && run.Step() {
+	}
+
+	return Result{run.Outcome(), run.Steps(), run.Configuration()}, nil
+}
+
+/*
+	Validates and solves a TM given a string like Solve, also returning every configuration of the run from the starting one.
+*/
+func (tm *TM) Trace(str string, limit int) ([]Configuration, Result, error) {
+	if limit < 0 {
+		return nil, Result{}, fmt.Errorf("the step limit '%v' must not be negative", limit)
+	}
+
+	run, err := tm.NewRun(str)
+	if err != nil {
+		return nil, Result{}, err
+	}
+
+	trace := []Configuration{run.Configuration()}
+	for run.Steps() < limit && run.Step() {
+		trace = append(trace, run.Configuration())
+	}
+
+	return trace, Result{run.Outcome(), run.Steps(), run.Configuration()}, nil
+}
+
+/*
+	Formats the outcome as 'accepted', 'rejected' or 'did not halt'.
+*/
+func (outcome Outcome) String() string {
+	switch outcome {
+	case Accepted:
+		return "accepted"
+	case Rejected:
+		return "rejected"
+	case DidNotHalt:
+		return "did not halt"
+	}
+
+	return fmt.Sprintf("Outcome(%d)", int(outcome))
+}
+
+/*
+	Formats the configuration as the tape with the state in brackets before the symbol under the head, as in 'ab[q1]ba'.
+*/
+func (configuration Configuration) String() string {
+	tape := []rune(configuration.Tape)
+
+	return fmt.Sprintf("%v[%v]%v", string(tape[:configuration.Head]), configuration.State, string(tape[configuration.Head:]))
+}
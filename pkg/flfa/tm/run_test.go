@@ -0,0 +1,167 @@
+package tm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/*
+	Creates the machine of the Turing Machine notebook, which accepts the strings ww of a string w repeated.
+	It marks the first half in uppercase and the second half with X and Y, and then crosses off matching symbols.
+*/
+func newRepeatTM(t *testing.T) TM {
+	t.Helper()
+
+	delta := Delta{
+		"q0": {'a': {"q1", 'A', Right}, 'b': {"q1", 'B', Right}, 'X': {"q4", 'X', Left}, 'Y': {"q4", 'Y', Left}, '_': {"q9", '_', Right}},
+		"q1": {'a': {"q1", 'a', Right}, 'b': {"q1", 'b', Right}, '_': {"q2", '_', Left}, 'X': {"q2", 'X', Left}, 'Y': {"q2", 'Y', Left}},
+		"q2": {'a': {"q3", 'X', Left}, 'b': {"q3", 'Y', Left}},
+		"q3": {'a': {"q3", 'a', Left}, 'b': {"q3", 'b', Left}, 'A': {"q0", 'A', Right}, 'B': {"q0", 'B', Right}},
+		"q4": {'A': {"q4", 'A', Left}, 'B': {"q4", 'B', Left}, '_': {"q5", '_', Right}},
+		"q5": {'A': {"q6", 'C', Right}, 'B': {"q7", 'C', Right}, 'D': {"q9", 'D', Left}},
+		"q6": {'A': {"q6", 'A', Right}, 'B': {"q6", 'B', Right}, 'D': {"q6", 'D', Right}, 'X': {"q8", 'D', Left}},
+		"q7": {'A': {"q7", 'A', Right}, 'B': {"q7", 'B', Right}, 'D': {"q7", 'D', Right}, 'Y': {"q8", 'D', Left}},
+		"q8": {'A': {"q8", 'A', Left}, 'B': {"q8", 'B', Left}, 'D': {"q8", 'D', Left}, 'C': {"q5", 'C', Right}},
+	}
+
+	tm, err := NewTM(
+		[]State{"q0", "q1", "q2", "q3", "q4", "q5", "q6", "q7", "q8", "q9", "qr"},
+		[]Symbol{'a', 'b'},
+		[]Symbol{'a', 'b', 'A', 'B', 'C', 'D', 'X', 'Y', '_'},
+		delta,
+		'_',
+		"q0",
+		"q9",
+		"qr",
+	)
+	require.NoError(t, err)
+
+	return tm
+}
+
+func TestSolve(t *testing.T) {
+	tm := newRepeatTM(t)
+
+	strs := []string{""}
+	for length := 0; length <= 6; length++ {
+		var next []string
+
+		for _, str := range strs {
+			want := Rejected
+			if len(str)%2 == 0 && str[:len(str)/2] == str[len(str)/2:] {
+				want = Accepted
+			}
+
+			result, err := tm.Solve(str, 1000)
+			assert.Equal(t, nil, err, "string '%v'", str)
+			assert.Equal(t, want, result.Outcome, "string '%v'", str)
+
+			next = append(next, str+"a", str+"b")
+		}
+
+		strs = next
+	}
+
+	result, err := tm.Solve("abab", 1000)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, Result{Accepted, 29, Configuration{"q9", "CCDD", 1}}, result)
+
+	_, err = tm.Solve("abc", 1000)
+	assert.Equal(t, fmt.Errorf("the symbol 'c' is not in the input alphabet"), err)
+
+	_, err = tm.Solve("ab", -1)
+	assert.Equal(t, fmt.Errorf("the step limit '-1' must not be negative"), err)
+}
+
+func TestSolveDidNotHalt(t *testing.T) {
+	// The machine moves right over blanks forever
+	tm, err := NewTM([]State{"q", "accept", "reject"}, []Symbol{'1'}, []Symbol{'1', '_'}, Delta{"q": {'1': {"q", '1', Right}, '_': {"q", '_', Right}}}, '_', "q", "accept", "reject")
+	assert.Equal(t, nil, err)
+
+	result, err := tm.Solve("11", 5)
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, DidNotHalt, result.Outcome)
+	assert.Equal(t, 5, result.Steps)
+	assert.Equal(t, Configuration{"q", "11____", 5}, result.Configuration)
+	assert.Equal(t, "did not halt", result.Outcome.String())
+}
+
+func TestTrace(t *testing.T) {
+	// The machine adds one to a binary number
+	tm, err := NewTM(
+		[]State{"right", "carry", "accept", "reject"},
+		[]Symbol{'0', '1'},
+		[]Symbol{'0', '1', '_'},
+		Delta{
+			"right": {'0': {"right", '0', Right}, '1': {"right", '1', Right}, '_': {"carry", '_', Left}},
+			"carry": {'1': {"carry", '0', Left}, '0': {"accept", '1', Stay}, '_': {"accept", '1', Stay}},
+		},
+		'_',
+		"right",
+		"accept",
+		"reject",
+	)
+	assert.Equal(t, nil, err)
+
+	trace, result, err := tm.Trace("11", 100)
+	assert.Equal(t, nil, err)
+
+	var configurations []string
+	for _, configuration := range trace {
+		configurations = append(configurations, configuration.String())
+	}
+
+	assert.Equal(t, []string{
+		"[right]11",
+		"1[right]1",
+		"11[right]_",
+		"1[carry]1",
+		"[carry]10",
+		"[carry]_00",
+		"[accept]100",
+	}, configurations)
+	assert.Equal(t, Result{Accepted, 6, Configuration{"accept", "100", 0}}, result)
+
+	trace, result, err = tm.Trace("11", 2)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, len(trace))
+	assert.Equal(t, DidNotHalt, result.Outcome)
+
+	// A missing transition rejects without moving
+	tm, err = NewTM([]State{"q", "accept", "reject"}, []Symbol{'0'}, []Symbol{'0', '_'}, Delta{}, '_', "q", "accept", "reject")
+	assert.Equal(t, nil, err)
+
+	trace, result, err = tm.Trace("0", 10)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []Configuration{{"q", "0", 0}, {"reject", "0", 0}}, trace)
+	assert.Equal(t, "rejected", result.Outcome.String())
+}
+
+func TestRun(t *testing.T) {
+	tm := newRepeatTM(t)
+
+	run, err := tm.NewRun("aa")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, Configuration{"q0", "aa", 0}, run.Configuration())
+
+	var tapes []string
+	for run.Step() {
+		configuration := run.Configuration()
+		tapes = append(tapes, configuration.Tape[:configuration.Head]+"^"+configuration.Tape[configuration.Head:])
+	}
+
+	assert.True(t, run.IsHalted())
+	assert.Equal(t, Accepted, run.Outcome())
+	assert.Equal(t, len(tapes), run.Steps())
+	assert.False(t, run.Step())
+	assert.Equal(t, "A^a", tapes[0])
+	assert.True(t, strings.HasPrefix(tapes[len(tapes)-1], "^"))
+
+	_, err = tm.NewRun("ax")
+	assert.Equal(t, fmt.Errorf("the symbol 'x' is not in the input alphabet"), err)
+}
@@ -0,0 +1,108 @@
+package tm
+
+/*
+	A tape infinite in both directions, storing the cells from the leftmost to the rightmost one ever written or visited.
+*/
+type tape struct {
+	cells  []Symbol
+	origin int
+	blank  Symbol
+}
+
+/*
+	Creates a tape holding a string from position 0 on.
+*/
+func newTape(str string, blank Symbol) *tape {
+	tape := &tape{nil, 0, blank}
+	for _, char := range str {
+		tape.cells = append(tape.cells, Symbol(char))
+	}
+
+	return tape
+}
+
+/*
+	Reads the symbol at a position.
+*/
+func (tape *tape) read(position int) Symbol {
+	i := position + tape.origin
+	if i < 0 || i >= len(tape.cells) {
+		return tape.blank
+	}
+
+	return tape.cells[i]
+}
+
+/*
+	Writes a symbol at a position, growing the tape if needed.
+*/
+func (tape *tape) write(position int, symbol Symbol) {
+	tape.grow(position)
+	tape.cells[position+tape.origin] = symbol
+}
+
+/*
+	Grows the tape with blanks so that it holds a position.
+*/
+func (tape *tape) grow(position int) {
+	for position+tape.origin < 0 {
+		tape.cells = append([]Symbol{tape.blank}, tape.cells...)
+		tape.origin++
+	}
+
+	for position+tape.origin >= len(tape.cells) {
+		tape.cells = append(tape.cells, tape.blank)
+	}
+}
+
+/*
+	Gets the contents of the tape from the leftmost to the rightmost cell that is not blank or under the head,
+	and the index of the head in the contents.
+*/
+func (tape *tape) contents(head int) (string, int) {
+	left, right := head, head
+	for i, symbol := range tape.cells {
+		if symbol == tape.blank {
+			continue
+		}
+
+		if i-tape.origin < left {
+			left = i - tape.origin
+		}
+
+		if i-tape.origin > right {
+			right = i - tape.origin
+		}
+	}
+
+	symbols := make([]rune, 0, right-left+1)
+	for position := left; position <= right; position++ {
+		symbols = append(symbols, rune(tape.read(position)))
+	}
+
+	return string(symbols), head - left
+}
+
+/*
+	Moves a head position.
+*/
+func move(position int, move Move) int {
+	switch move {
+	case Left:
+		return position - 1
+	case Right:
+		return position + 1
+	}
+
+	return position
+}
+
+/*
+	Copies the tape, so that computations can write on their own tapes.
+*/
+func (tape *tape) clone() *tape {
+	clone := *tape
+	clone.cells = append([]Symbol(nil), tape.cells...)
+
+	return &clone
+}
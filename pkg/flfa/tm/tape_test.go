@@ -0,0 +1,49 @@
+package tm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTape(t *testing.T) {
+	tape := newTape("ab", '_')
+
+	assert.Equal(t, Symbol('a'), tape.read(0))
+	assert.Equal(t, Symbol('_'), tape.read(-1))
+	assert.Equal(t, Symbol('_'), tape.read(5))
+
+	tape.write(-2, 'x')
+	tape.write(3, 'y')
+
+	assert.Equal(t, Symbol('x'), tape.read(-2))
+	assert.Equal(t, Symbol('y'), tape.read(3))
+
+	var tests = []struct {
+		head     int
+		wantTape string
+		wantHead int
+	}{
+		{0, "x_ab_y", 2},
+		{-4, "__x_ab_y", 0},
+		{6, "x_ab_y___", 8},
+	}
+
+	for _, tt := range tests {
+		contents, head := tape.contents(tt.head)
+
+		assert.Equal(t, tt.wantTape, contents, "head '%v'", tt.head)
+		assert.Equal(t, tt.wantHead, head, "head '%v'", tt.head)
+	}
+
+	// A blank tape only shows the cell under the head
+	contents, head := newTape("", '_').contents(3)
+	assert.Equal(t, "_", contents)
+	assert.Equal(t, 0, head)
+}
+
+func TestMove(t *testing.T) {
+	assert.Equal(t, 2, move(3, Left))
+	assert.Equal(t, 4, move(3, Right))
+	assert.Equal(t, 3, move(3, Stay))
+}
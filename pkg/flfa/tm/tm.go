@@ -0,0 +1,229 @@
+package tm
+
+import (
+	"fmt"
+)
+
+type State string
+type Symbol rune
+
+/*
+	A direction the head moves in after writing.
+*/
+type Move int
+
+const (
+	Left Move = iota
+	Right
+	Stay
+)
+
+/*
+	What a machine does in a state reading a symbol: the state it enters, the symbol it writes and the way its head moves.
+*/
+type Action struct {
+	State State
+	Write Symbol
+	Move  Move
+}
+
+type Delta map[State]map[Symbol]Action
+
+/*
+	A single-tape deterministic Turing machine.
+	The tape is infinite in both directions and blank outside of the input, and the head starts on the first input symbol.
+	The machine halts when it enters the accepting or the rejecting state,
+	and a missing transition is a move into the rejecting state.
+*/
+type TM struct {
+	states         []State
+	inputAlphabet  []Symbol
+	tapeAlphabet   []Symbol
+	delta          Delta
+	blank          Symbol
+	startingState  State
+	acceptingState State
+	rejectingState State
+}
+
+/*
+	Creates a TM and validates it.
+	If the TM fails validation, then an empty TM is returned.
+*/
+func NewTM(states []State, inputAlphabet []Symbol, tapeAlphabet []Symbol, delta Delta, blank Symbol, startingState State, acceptingState State, rejectingState State) (TM, error) {
+	tm := TM{states, inputAlphabet, tapeAlphabet, delta, blank, startingState, acceptingState, rejectingState}
+
+	err := tm.validate()
+	if err != nil {
+		return TM{}, err
+	}
+
+	return tm, nil
+}
+
+/*
+	Gets the TM's blank symbol.
+*/
+func (tm *TM) Blank() Symbol {
+	return tm.blank
+}
+
+/*
+	Gets the TM's starting state.
+*/
+func (tm *TM) StartingState() State {
+	return tm.startingState
+}
+
+/*
+	Gets the TM's accepting state.
+*/
+func (tm *TM) AcceptingState() State {
+	return tm.acceptingState
+}
+
+/*
+	Gets the TM's rejecting state.
+*/
+func (tm *TM) RejectingState() State {
+	return tm.rejectingState
+}
+
+/*
+	Formats the move as 'L', 'R' or 'S'.
+*/
+func (move Move) String() string {
+	switch move {
+	case Left:
+		return "L"
+	case Right:
+		return "R"
+	case Stay:
+		return "S"
+	}
+
+	return fmt.Sprintf("Move(%d)", int(move))
+}
+
+/*
+	Validates the TM.
+*/
+func (tm *TM) validate() error {
+	err := validateAlphabets(tm.inputAlphabet, tm.tapeAlphabet, tm.blank)
+	if err != nil {
+		return err
+	}
+
+	for _, state := range []State{tm.startingState, tm.acceptingState, tm.rejectingState} {
+		err = validateState(state, tm.states)
+		if err != nil {
+			return err
+		}
+	}
+
+	if tm.acceptingState == tm.rejectingState {
+		return fmt.Errorf("the accepting and rejecting states cannot both be '%v'", tm.acceptingState)
+	}
+
+	for state, transitions := range tm.delta {
+		err = validateState(state, tm.states)
+		if err != nil {
+			return err
+		}
+
+		if (state == tm.acceptingState || state == tm.rejectingState) && len(transitions) != 0 {
+			return fmt.Errorf("the halting state '%v' cannot have transitions", state)
+		}
+
+		for symbol, action := range transitions {
+			err = validateSymbol(symbol, tm.tapeAlphabet, "tape")
+			if err != nil {
+				return err
+			}
+
+			err = validateAction(action, tm.states, tm.tapeAlphabet)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+/*
+	Validates that the blank symbol and the input alphabet are in the tape alphabet, and the blank symbol is not in the input alphabet.
+*/
+func validateAlphabets(inputAlphabet []Symbol, tapeAlphabet []Symbol, blank Symbol) error {
+	err := validateSymbol(blank, tapeAlphabet, "tape")
+	if err != nil {
+		return err
+	}
+
+	for _, symbol := range inputAlphabet {
+		if symbol == blank {
+			return fmt.Errorf("the blank symbol '%c' cannot be in the input alphabet", blank)
+		}
+
+		err = validateSymbol(symbol, tapeAlphabet, "tape")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/*
+	Validates the state, written symbol and move of an action.
+*/
+func validateAction(action Action, states []State, tapeAlphabet []Symbol) error {
+	err := validateState(action.State, states)
+	if err != nil {
+		return err
+	}
+
+	err = validateSymbol(action.Write, tapeAlphabet, "tape")
+	if err != nil {
+		return err
+	}
+
+	return validateMove(action.Move)
+}
+
+/*
+	Validates a given state against the states.
+*/
+func validateState(state State, states []State) error {
+	for _, possibleState := range states {
+		if state == possibleState {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("the state '%v' is not in the states", state)
+}
+
+/*
+	Validates a given symbol against an alphabet, named in the error.
+*/
+func validateSymbol(symbol Symbol, alphabet []Symbol, name string) error {
+	for _, possibleSymbol := range alphabet {
+		if symbol == possibleSymbol {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("the symbol '%c' is not in the %v alphabet", symbol, name)
+}
+
+/*
+	Validates that a move is left, right or stay.
+*/
+func validateMove(move Move) error {
+	if move != Left && move != Right && move != Stay {
+		return fmt.Errorf("the move '%v' must be either left, right or stay", move)
+	}
+
+	return nil
+}
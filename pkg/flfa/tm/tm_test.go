@@ -0,0 +1,42 @@
+package tm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTM(t *testing.T) {
+	var tests = []struct {
+		inputAlphabet  []Symbol
+		delta          Delta
+		acceptingState State
+		rejectingState State
+		want           error
+	}{
+		{[]Symbol{'a'}, Delta{"q": {'a': {"accept", '_', Right}}}, "accept", "reject", nil},
+		{[]Symbol{'a', '_'}, Delta{}, "accept", "reject", fmt.Errorf("the blank symbol '_' cannot be in the input alphabet")},
+		{[]Symbol{'b'}, Delta{}, "accept", "reject", fmt.Errorf("the symbol 'b' is not in the tape alphabet")},
+		{[]Symbol{'a'}, Delta{}, "halt", "reject", fmt.Errorf("the state 'halt' is not in the states")},
+		{[]Symbol{'a'}, Delta{}, "accept", "accept", fmt.Errorf("the accepting and rejecting states cannot both be 'accept'")},
+		{[]Symbol{'a'}, Delta{"p": {}}, "accept", "reject", fmt.Errorf("the state 'p' is not in the states")},
+		{[]Symbol{'a'}, Delta{"accept": {'a': {"q", 'a', Left}}}, "accept", "reject", fmt.Errorf("the halting state 'accept' cannot have transitions")},
+		{[]Symbol{'a'}, Delta{"q": {'b': {"q", 'a', Left}}}, "accept", "reject", fmt.Errorf("the symbol 'b' is not in the tape alphabet")},
+		{[]Symbol{'a'}, Delta{"q": {'a': {"q", 'b', Left}}}, "accept", "reject", fmt.Errorf("the symbol 'b' is not in the tape alphabet")},
+		{[]Symbol{'a'}, Delta{"q": {'a': {"p", 'a', Left}}}, "accept", "reject", fmt.Errorf("the state 'p' is not in the states")},
+		{[]Symbol{'a'}, Delta{"q": {'a': {"q", 'a', Move(3)}}}, "accept", "reject", fmt.Errorf("the move 'Move(3)' must be either left, right or stay")},
+	}
+
+	for _, tt := range tests {
+		_, err := NewTM([]State{"q", "accept", "reject"}, tt.inputAlphabet, []Symbol{'a', '_'}, tt.delta, '_', "q", tt.acceptingState, tt.rejectingState)
+
+		assert.Equal(t, tt.want, err)
+	}
+}
+
+func TestMoveString(t *testing.T) {
+	assert.Equal(t, "L", Left.String())
+	assert.Equal(t, "R", Right.String())
+	assert.Equal(t, "S", Stay.String())
+}